@@ -8,7 +8,15 @@ func (m model) Kontext() string { return strings.TrimSpace(m.kontext.Value()) }
 
 func (m model) Entscheidung() string { return m.entscheidung.Markdown() }
 func (m model) Konsequenzen() string { return m.konsequenzen.Markdown() }
-func (m model) Alternativen() string { return m.alternativen.Markdown() }
+
+// Alternativen ist leer, solange nichts eingetragen ist – buildMarkdown setzt
+// dann den eigenen Platzhalter „(keine oder noch offen)“.
+func (m model) Alternativen() string {
+	if m.alternativen.NonEmptyCount() == 0 {
+		return ""
+	}
+	return m.alternativen.Markdown()
+}
 
 func (m model) Beteiligte() string { return trimJoin(splitCSV(m.beteiligte.Value())) }
 func (m model) Tags() string       { return trimJoin(splitCSV(m.tags.Value())) }
@@ -1,7 +1,6 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -23,6 +22,10 @@ type parsedADR struct {
 	Entscheidung string
 	Alternativen string
 	Konsequenzen string
+	Pruefregeln  string
+	Verweise     string
+	Autor        string
+	SigningKey   string
 }
 
 func (m *model) loadFromFile(path string) error {
@@ -30,14 +33,18 @@ func (m *model) loadFromFile(path string) error {
 	if err != nil {
 		return err
 	}
-	txt := string(content)
+	m.fillFromParsed(parseADRText(path, string(content)))
+	m.rememberBase(path, string(content))
+	return nil
+}
 
+// parseADRText zerlegt den Markdown-Inhalt eines ADR; path dient nur zur
+// Nummernerkennung über den Dateinamen.
+func parseADRText(path, txt string) parsedADR {
 	pa := parsedADR{}
 
 	base := filepath.Base(path)
-	if mm := adrFileRe.FindStringSubmatch(base); len(mm) == 2 {
-		fmt.Sscanf(mm[1], "%04d", &pa.No)
-	}
+	pa.No, _ = adrNumber(base)
 
 	// neu: Titel defensiv bereinigen – Tabellenzeile nicht als Titel übernehmen
 	h1 := regexp.MustCompile(`(?m)^#\s*(?:ADR\s+(\d+):\s*)?(.*)$`).FindStringSubmatch(txt)
@@ -75,6 +82,10 @@ func (m *model) loadFromFile(path string) error {
 			pa.Beteiligte = val
 		case "tags":
 			pa.Tags = val
+		case "autor":
+			pa.Autor = val
+		case "signing-key":
+			pa.SigningKey = val
 		}
 	}
 
@@ -82,9 +93,11 @@ func (m *model) loadFromFile(path string) error {
 	pa.Entscheidung = extractSection(txt, "Entscheidung")
 	pa.Alternativen = extractSection(txt, "Alternativen")
 	pa.Konsequenzen = extractSection(txt, "Konsequenzen")
-
-	m.fillFromParsed(pa)
-	return nil
+	pa.Pruefregeln = extractSection(txt, rulesHeading)
+	if v := extractSection(txt, "Verweise"); v != "-" { // "- " ist der Platzhalter neuer ADRs
+		pa.Verweise = v
+	}
+	return pa
 }
 
 func extractSection(txt, heading string) string {
@@ -152,6 +165,9 @@ func (m *model) fillFromParsed(p parsedADR) {
 	m.lastEditedBy = strings.TrimSpace(p.LastEditedBy)
 	m.lastEditedAt = strings.TrimSpace(p.LastEditedAt)
 	m.editingNo = p.No
+	m.pruefregeln = p.Pruefregeln
+	m.verweise = p.Verweise
+	m.autor, m.signingKey = p.Autor, p.SigningKey
 }
 
 func parseADRForSearch(path string) searchDoc {
@@ -159,8 +175,10 @@ func parseADRForSearch(path string) searchDoc {
 	if err != nil {
 		return searchDoc{}
 	}
-	txt := string(b)
+	return searchDocFromText(string(b))
+}
 
+func searchDocFromText(txt string) searchDoc {
 	// Titel + Nummer
 	h1 := regexp.MustCompile(`(?m)^#\s*(?:ADR\s+\d+:\s*)?(.*)$`).FindStringSubmatch(txt)
 	title := ""
@@ -173,11 +191,21 @@ func parseADRForSearch(path string) searchDoc {
 
 	// Tabellen-Felder
 	rowRe := regexp.MustCompile(`(?m)^\|\s*([^|]+?)\s*\|\s*([^|]+?)\s*\|`)
-	var status, beteiligte, tags string
+	var status, beteiligte, tags, created, editedBy, editedAt string
 	for _, mm := range rowRe.FindAllStringSubmatch(txt, -1) {
 		key := strings.TrimSpace(strings.ToLower(mm[1]))
 		val := strings.TrimSpace(mm[2])
 		switch key {
+		case "datum (erstellt)":
+			created = val
+		case "datum":
+			if created == "" {
+				created = val
+			}
+		case "zuletzt editiert von":
+			editedBy = val
+		case "zuletzt editiert am":
+			editedAt = val
 		case "status":
 			status = val
 		case "beteiligte":
@@ -196,23 +224,15 @@ func parseADRForSearch(path string) searchDoc {
 	return searchDoc{
 		Title: title, Status: status, Beteiligte: beteiligte, Tags: tags,
 		Kontext: kontext, Entscheidung: entscheidung, Alternativen: alternativen, Konsequenzen: konsequenzen,
+		CreatedDate: created, LastEditedBy: editedBy, LastEditedAt: editedAt,
 	}
 }
 
 func parseDraftForSearch(path string) searchDoc {
-	b, err := os.ReadFile(path)
+	d, err := readDraftFile(path)
 	if err != nil {
 		return searchDoc{}
 	}
-	var d struct {
-		Title, Kontext                           string
-		Entscheidung, Konsequenzen, Alternativen []string
-		Beteiligte, Tags                         string
-		StatusIdx                                int
-	}
-	if json.Unmarshal(b, &d) != nil {
-		return searchDoc{}
-	}
 	status := ""
 	if d.StatusIdx >= 0 && d.StatusIdx < len(statuses) {
 		status = statuses[d.StatusIdx]
@@ -226,5 +246,6 @@ func parseDraftForSearch(path string) searchDoc {
 		Entscheidung: strings.Join(d.Entscheidung, " "),
 		Alternativen: strings.Join(d.Alternativen, " "),
 		Konsequenzen: strings.Join(d.Konsequenzen, " "),
+		CreatedDate:  d.CreatedDate,
 	}
 }
@@ -6,10 +6,7 @@ import (
 	"time"
 )
 
-const (
-	autosaveDir      = ".adronaut"
-	autosaveInterval = 3 * time.Second
-)
+const autosaveInterval = 3 * time.Second
 
 func scheduleAutosave() tea.Cmd {
 	return tea.Tick(autosaveInterval, func(time.Time) tea.Msg { return autosaveTickMsg{} })
@@ -18,11 +15,27 @@ func scheduleAutosave() tea.Cmd {
 func autosaveCmd(m model) tea.Cmd {
 	df := m.toDraft()
 	path := m.draftPath()
+	li := m.lock
+	uf := m.toUndoFile()
 	return func() tea.Msg {
-		b, err := json.MarshalIndent(df, "", "  ")
-		if err == nil {
-			err = atomicWrite(path, b, 0o644)
+		if err := refreshLock(li); err != nil {
+			return autosaveDoneMsg{path: path, err: err}
+		}
+		if err := writeDraft(path, df); err != nil {
+			return autosaveDoneMsg{path: path, err: err}
+		}
+		if err := writeUndo(path, uf); err != nil {
+			return autosaveDoneMsg{path: path, err: err}
 		}
-		return autosaveDoneMsg{path: path, err: err}
+		return autosaveDoneMsg{path: path, err: recordSnapshot(path, df)}
+	}
+}
+
+func writeDraft(path string, df draftFile) error {
+	df.SchemaVersion = draftSchemaVersion
+	b, err := json.MarshalIndent(df, "", "  ")
+	if err != nil {
+		return err
 	}
+	return atomicWrite(path, b, 0o644)
 }
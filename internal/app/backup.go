@@ -0,0 +1,209 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+/* ------------------ Sicherungen vor dem Überschreiben --------------------- */
+
+const (
+	backupKeep   = 10 // Sicherungen je Datei
+	backupLayout = "20060102-150405.000000"
+)
+
+var backupName = regexp.MustCompile(`^(.+)\.(\d{8}-\d{6}\.\d{6})\.bak$`)
+
+func backupDir() string { return filepath.Join(metaDir, "backups") }
+
+// backupEntry ist eine Sicherung; der ursprüngliche Pfad steckt
+// URL-kodiert im Dateinamen, damit das Verzeichnis flach bleibt.
+type backupEntry struct {
+	Name   string // Dateiname in backupDir
+	Source string // ursprünglicher Pfad
+	Time   time.Time
+	Size   int64
+}
+
+func listBackups() ([]backupEntry, error) {
+	entries, err := os.ReadDir(backupDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []backupEntry
+	for _, e := range entries {
+		mm := backupName.FindStringSubmatch(e.Name())
+		if e.IsDir() || mm == nil {
+			continue
+		}
+		src, err := url.PathUnescape(mm[1])
+		if err != nil {
+			continue
+		}
+		t, _ := time.ParseInLocation(backupLayout, mm[2], time.Local)
+		be := backupEntry{Name: e.Name(), Source: src, Time: t}
+		if fi, err := e.Info(); err == nil {
+			be.Size = fi.Size()
+		}
+		out = append(out, be)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Source != out[j].Source {
+			return out[i].Source < out[j].Source
+		}
+		return out[i].Time.After(out[j].Time) // neueste zuerst
+	})
+	return out, nil
+}
+
+// backupFile sichert den aktuellen Inhalt von path, falls vorhanden und nicht
+// schon identisch gesichert, und dünnt ältere Sicherungen aus.
+func backupFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	src := filepath.ToSlash(filepath.Clean(path))
+	all, err := listBackups()
+	if err != nil {
+		return err
+	}
+	var own []backupEntry
+	for _, b := range all {
+		if b.Source == src {
+			own = append(own, b)
+		}
+	}
+	if len(own) > 0 {
+		if prev, err := os.ReadFile(filepath.Join(backupDir(), own[0].Name)); err == nil && bytes.Equal(prev, data) {
+			return nil
+		}
+	}
+	dir, err := ensureMetaDir("backups")
+	if err != nil {
+		return err
+	}
+	name := url.PathEscape(src) + "." + time.Now().Format(backupLayout) + ".bak"
+	if err := atomicWrite(filepath.Join(dir, name), data, 0o644); err != nil {
+		return err
+	}
+	for i := backupKeep - 1; i < len(own); i++ {
+		_ = os.Remove(filepath.Join(backupDir(), own[i].Name))
+	}
+	return nil
+}
+
+// publishADR schreibt content nach path. Bei einer Umbenennung wird die alte
+// Datei zuerst atomar verschoben (versioniert per git mv) – ein Absturz
+// hinterlässt also nie zwei Kopien, sondern höchstens den alten Inhalt unter
+// dem neuen Namen.
+func publishADR(oldPath, path, content string) error {
+	if oldPath != "" {
+		if err := backupFile(oldPath); err != nil {
+			return fmt.Errorf("Sicherung fehlgeschlagen: %w", err)
+		}
+	}
+	if oldPath != "" && oldPath != path {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s existiert bereits", path)
+		}
+		if err := ensureDir(filepath.Dir(path)); err != nil {
+			return err
+		}
+		if _, err := os.Stat(oldPath); err == nil {
+			if err := gitMove(oldPath, path); err != nil {
+				return err
+			}
+		}
+	}
+	return atomicWrite(path, []byte(content), 0o644)
+}
+
+/* ------------------------- adronaut restore ------------------------------- */
+
+func runRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	to := fs.String("to", "", "an diesen Pfad statt an den ursprünglichen wiederherstellen")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Benutzung: adronaut restore [datei]        Sicherungen auflisten")
+		fmt.Fprintln(fs.Output(), "           adronaut restore <sicherung>   Sicherung wiederherstellen")
+		fs.PrintDefaults()
+	}
+	pos, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	all, err := listBackups()
+	if err != nil {
+		return err
+	}
+	arg := ""
+	if len(pos) > 0 {
+		arg = pos[0]
+	}
+	for _, b := range all {
+		if arg != "" && b.Name == arg {
+			return restoreBackup(b, userPath(*to))
+		}
+	}
+
+	filter := filepath.ToSlash(filepath.Clean(userPath(arg)))
+	shown := 0
+	last := ""
+	for _, b := range all {
+		if arg != "" && b.Source != filter && filepath.Base(b.Source) != arg {
+			continue
+		}
+		if b.Source != last {
+			fmt.Println(labelStyle.Render(b.Source))
+			last = b.Source
+		}
+		fmt.Printf("  %s  %6d B  %s\n", b.Time.Format("2006-01-02 15:04:05"), b.Size, b.Name)
+		shown++
+	}
+	if shown == 0 {
+		if arg != "" {
+			return fmt.Errorf("keine Sicherung für %q gefunden", arg)
+		}
+		fmt.Println("Keine Sicherungen vorhanden.")
+		return nil
+	}
+	fmt.Println(helpStyle.Render("Wiederherstellen mit: adronaut restore <sicherung>"))
+	return nil
+}
+
+// restoreBackup schreibt eine Sicherung zurück; der aktuelle Stand wird
+// vorher selbst gesichert, die Wiederherstellung ist also umkehrbar.
+func restoreBackup(b backupEntry, to string) error {
+	target := filepath.FromSlash(b.Source)
+	if strings.TrimSpace(to) != "" {
+		target = to
+	}
+	data, err := os.ReadFile(filepath.Join(backupDir(), b.Name))
+	if err != nil {
+		return err
+	}
+	if err := backupFile(target); err != nil {
+		return fmt.Errorf("Sicherung fehlgeschlagen: %w", err)
+	}
+	if err := atomicWrite(target, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Println(okStyle.Render("✔ wiederhergestellt: ") + target + " (Stand " + b.Time.Format("2006-01-02 15:04:05") + ")")
+	return nil
+}
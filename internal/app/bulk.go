@@ -0,0 +1,396 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ---------------- Mehrfachauswahl und Massenänderungen -------------------- */
+
+// bulkOp beschreibt eine Änderung, die auf mehrere ADRs angewendet wird.
+type bulkOp struct {
+	SetStatus          string
+	AddTags            []string
+	RemoveTags         []string
+	RenameTags         map[string]string // alt -> neu
+	RenameParticipants map[string]string // alt -> neu
+}
+
+func (op bulkOp) empty() bool {
+	return op.SetStatus == "" && len(op.AddTags) == 0 && len(op.RemoveTags) == 0 &&
+		len(op.RenameTags) == 0 && len(op.RenameParticipants) == 0
+}
+
+func (op bulkOp) apply(e *model) {
+	if op.SetStatus != "" {
+		e.statusIdx = statusRank(op.SetStatus) % len(statuses)
+	}
+	tags := splitCSV(e.tags.Value())
+	tags = renameInList(tags, op.RenameTags)
+	for _, t := range op.RemoveTags {
+		tags = removeFromList(tags, t)
+	}
+	for _, t := range op.AddTags {
+		if indexFold(tags, t) < 0 {
+			tags = append(tags, t)
+		}
+	}
+	e.tags.SetValue(trimJoin(tags))
+	e.beteiligte.SetValue(trimJoin(renameInList(splitCSV(e.beteiligte.Value()), op.RenameParticipants)))
+}
+
+func (op bulkOp) String() string {
+	var parts []string
+	if op.SetStatus != "" {
+		parts = append(parts, "Status → "+op.SetStatus)
+	}
+	if len(op.AddTags) > 0 {
+		parts = append(parts, "Tags + "+trimJoin(op.AddTags))
+	}
+	if len(op.RemoveTags) > 0 {
+		parts = append(parts, "Tags − "+trimJoin(op.RemoveTags))
+	}
+	for _, k := range sortedKeys(op.RenameTags) {
+		parts = append(parts, fmt.Sprintf("Tag %s → %s", k, op.RenameTags[k]))
+	}
+	for _, k := range sortedKeys(op.RenameParticipants) {
+		parts = append(parts, fmt.Sprintf("Beteiligte %s → %s", k, op.RenameParticipants[k]))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func indexFold(list []string, s string) int {
+	for i, v := range list {
+		if strings.EqualFold(v, s) {
+			return i
+		}
+	}
+	return -1
+}
+
+func removeFromList(list []string, s string) []string {
+	out := list[:0:0]
+	for _, v := range list {
+		if !strings.EqualFold(v, s) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// renameInList benennt Einträge um und entfernt dabei entstehende Dubletten.
+func renameInList(list []string, ren map[string]string) []string {
+	if len(ren) == 0 {
+		return list
+	}
+	out := make([]string, 0, len(list))
+	for _, v := range list {
+		for from, to := range ren {
+			if strings.EqualFold(v, from) {
+				v = to
+				break
+			}
+		}
+		if indexFold(out, v) < 0 {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseRenames liest "alt=neu, alt2=neu2".
+func parseRenames(s string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, p := range splitCSV(s) {
+		from, to, ok := strings.Cut(p, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("Umbenennung %q: erwartet alt=neu", p)
+		}
+		out[from] = to
+	}
+	return out, nil
+}
+
+// bulkChange ist ein Eintrag der Vorschau: was sich an welcher Datei ändert.
+type bulkChange struct {
+	Opt     fileOption
+	Changes []string
+}
+
+// planBulk lädt jede Datei über den Parser, wendet op an und merkt sich nur
+// Dateien, deren Status, Tags oder Beteiligte sich tatsächlich ändern.
+func planBulk(m model, targets []fileOption, op bulkOp) ([]bulkChange, error) {
+	var plan []bulkChange
+	for _, o := range targets {
+		if o.Path == newAdrSentinel || o.Rev != "" {
+			continue
+		}
+		if li, held := m.lockedByOther(o); held {
+			return nil, fmt.Errorf("%s wird gerade von %s bearbeitet", o.Path, li.owner())
+		}
+		e, err := m.loadOption(o)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", o.Path, err)
+		}
+		if err := e.checkSections(); err != nil {
+			return nil, err
+		}
+		before := [3]string{e.Status(), e.Tags(), e.Beteiligte()}
+		op.apply(&e)
+		after := [3]string{e.Status(), e.Tags(), e.Beteiligte()}
+		var ch []string
+		for i, lbl := range []string{"Status", "Tags", "Beteiligte"} {
+			if before[i] != after[i] {
+				ch = append(ch, fmt.Sprintf("%s: %s → %s", lbl, orDash(before[i]), orDash(after[i])))
+			}
+		}
+		if len(ch) > 0 {
+			plan = append(plan, bulkChange{Opt: o, Changes: ch})
+		}
+	}
+	return plan, nil
+}
+
+func orDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "–"
+	}
+	return s
+}
+
+// applyBulk schreibt alle geplanten Änderungen über editOption, also wie
+// ein Speichern im Assistenten.
+func applyBulk(m model, plan []bulkChange, op bulkOp) []quickActionDoneMsg {
+	res := make([]quickActionDoneMsg, 0, len(plan))
+	for _, c := range plan {
+		p, err := m.editOption(c.Opt, op.apply)
+		res = append(res, quickActionDoneMsg{oldPath: c.Opt.Path, newPath: p, err: err})
+	}
+	return res
+}
+
+// bulkPreviewMax begrenzt die Vorschau im Picker; die CLI zeigt alles.
+const bulkPreviewMax = 8
+
+// formatBulkPlan listet die betroffenen Dateien; limit <= 0 heißt alle.
+func formatBulkPlan(plan []bulkChange, limit int) string {
+	if len(plan) == 0 {
+		return "Keine Datei wäre betroffen."
+	}
+	var b strings.Builder
+	for i, c := range plan {
+		if limit > 0 && i == limit {
+			fmt.Fprintf(&b, "… und %d weitere\n", len(plan)-limit)
+			break
+		}
+		fmt.Fprintf(&b, "%s\n", c.Opt.Path)
+		for _, ch := range c.Changes {
+			fmt.Fprintf(&b, "    %s\n", ch)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+type bulkDoneMsg struct {
+	results []quickActionDoneMsg
+}
+
+func bulkCmd(m model, plan []bulkChange, op bulkOp) tea.Cmd {
+	return func() tea.Msg {
+		return bulkDoneMsg{results: applyBulk(m, plan, op)}
+	}
+}
+
+func (m model) handleBulkDone(msg bulkDoneMsg) model {
+	failed := 0
+	for _, r := range msg.results {
+		if r.err != nil {
+			failed++
+			m.err = fmt.Errorf("%s: %w", r.oldPath, r.err)
+			continue
+		}
+		m.replaceOption(r.oldPath, r.newPath)
+	}
+	m.selected = map[string]bool{}
+	m.bulkPlan = nil
+	m.notice = fmt.Sprintf("%d Datei(en) geändert", len(msg.results)-failed)
+	if failed == 0 {
+		m.err = nil
+	}
+	return m
+}
+
+/* ------------------------------ Auswahl ----------------------------------- */
+
+func (m *model) toggleSelected() {
+	o, ok := m.selectedOption()
+	if !ok || o.Rev != "" {
+		return
+	}
+	if m.selected == nil {
+		m.selected = map[string]bool{}
+	}
+	if m.selected[o.Path] {
+		delete(m.selected, o.Path)
+	} else {
+		m.selected[o.Path] = true
+	}
+}
+
+// toggleSelectAll markiert alle sichtbaren Einträge bzw. hebt die Auswahl auf.
+func (m *model) toggleSelectAll() {
+	if len(m.selected) > 0 {
+		m.selected = map[string]bool{}
+		return
+	}
+	m.selected = map[string]bool{}
+	for _, o := range m.pickOptions {
+		if o.Path != newAdrSentinel && o.Rev == "" {
+			m.selected[o.Path] = true
+		}
+	}
+}
+
+// bulkTargets liefert die markierten Einträge in Listenreihenfolge.
+func (m model) bulkTargets() []fileOption {
+	var out []fileOption
+	for _, o := range m.allOptions {
+		if m.selected[o.Path] {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+/* --------------------------- --where Abfragen ----------------------------- */
+
+type whereTerm struct {
+	key, op, val string
+}
+
+// parseWhere liest Ausdrücke wie
+//
+//	status=Vorgeschlagen tag=sicherheit beteiligte~platform titel~mesh ns=payments
+//
+// "=" vergleicht exakt (bei Tags/Beteiligten je Eintrag), "~" sucht Teilstrings,
+// "!=" verneint; ein Wort ohne Operator sucht im Volltext. Werte mit
+// Leerzeichen stehen in Anführungszeichen: titel~"service mesh". Alle Terme
+// müssen zutreffen.
+func parseWhere(q string) ([]whereTerm, error) {
+	fields, err := splitQuoted(q)
+	if err != nil {
+		return nil, err
+	}
+	var terms []whereTerm
+	for _, f := range fields {
+		t := whereTerm{val: f}
+		for _, op := range []string{"!=", "=", "~"} {
+			if k, v, ok := strings.Cut(f, op); ok {
+				t = whereTerm{key: strings.ToLower(k), op: op, val: v}
+				break
+			}
+		}
+		switch t.key {
+		case "", "status", "tag", "tags", "beteiligte", "titel", "title", "nr", "ns", "erstellt":
+		default:
+			return nil, fmt.Errorf("unbekanntes Feld %q in --where", t.key)
+		}
+		terms = append(terms, t)
+	}
+	return terms, nil
+}
+
+// splitQuoted trennt an Leerzeichen außerhalb von "…" bzw. '…'; die
+// Anführungszeichen selbst fallen weg.
+func splitQuoted(q string) ([]string, error) {
+	var out []string
+	var cur strings.Builder
+	var quote rune
+	inField := false
+	for _, r := range q {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			cur.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inField = r, true
+		case r == ' ' || r == '\t':
+			if inField {
+				out = append(out, cur.String())
+				cur.Reset()
+				inField = false
+			}
+		default:
+			cur.WriteRune(r)
+			inField = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("--where: Anführungszeichen %c nicht geschlossen", quote)
+	}
+	if inField {
+		out = append(out, cur.String())
+	}
+	return out, nil
+}
+
+func whereMatches(terms []whereTerm, o fileOption, d searchDoc) bool {
+	for _, t := range terms {
+		if !t.matches(o, d) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t whereTerm) matches(o fileOption, d searchDoc) bool {
+	val := strings.ToLower(t.val)
+	var field string
+	var list []string
+	switch t.key {
+	case "":
+		return strings.Contains(d.Full, val)
+	case "status":
+		field = d.Status
+	case "tag", "tags":
+		field, list = d.Tags, splitCSV(d.Tags)
+	case "beteiligte":
+		field, list = d.Beteiligte, splitCSV(d.Beteiligte)
+	case "titel", "title":
+		field = d.Title
+	case "nr":
+		field = fmt.Sprintf("%04d", o.No)
+		val = fmt.Sprintf("%04s", val)
+	case "ns":
+		field = o.NS
+	case "erstellt":
+		field = d.CreatedDate
+	}
+	var hit bool
+	switch {
+	case t.op == "~":
+		hit = strings.Contains(strings.ToLower(field), val)
+	case list != nil:
+		hit = indexFold(list, val) >= 0
+	default:
+		hit = strings.EqualFold(field, val)
+	}
+	if t.op == "!=" {
+		return !hit
+	}
+	return hit
+}
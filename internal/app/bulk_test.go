@@ -0,0 +1,165 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const bulkFixture = `# ADR 0007: Wahl des Service Mesh
+
+| Feld | Wert |
+|------|------|
+| Datum (erstellt) | 2024-03-01 |
+| Status | Vorgeschlagen |
+| Autor | Alice <a@x> |
+| Signing-Key | ABCDEF |
+| Zuletzt editiert von | Alice |
+| Zuletzt editiert am | 2024-03-02 |
+| Beteiligte | Alice, Bob |
+
+## Kontext
+Wir brauchen mTLS.
+
+## Entscheidung
+1. Linkerd
+
+   mit zweitem Absatz
+
+## Alternativen
+(keine oder noch offen)
+
+## Konsequenzen
+(noch offen)
+
+## Verweise
+- [ADR-0003](ADR-0003-netz.md)
+`
+
+// Eine Massenänderung ändert die betroffene Tabellenzeile und „Zuletzt
+// editiert“, alles andere bleibt wie in der Datei.
+func TestBulkKeepsEverythingButTheEditedField(t *testing.T) {
+	tests := []struct {
+		name string
+		op   bulkOp
+		want func(string) string
+	}{
+		{
+			name: "status",
+			op:   bulkOp{SetStatus: "Angenommen"},
+			want: func(s string) string {
+				return strings.Replace(s, "| Status | Vorgeschlagen |", "| Status | Angenommen |", 1)
+			},
+		},
+		{
+			name: "tag hinzufügen",
+			op:   bulkOp{AddTags: []string{"netz"}},
+			want: func(s string) string {
+				return strings.Replace(s, "| Beteiligte | Alice, Bob |\n", "| Beteiligte | Alice, Bob |\n| Tags | netz |\n", 1)
+			},
+		},
+		{
+			name: "beteiligte umbenennen",
+			op:   bulkOp{RenameParticipants: map[string]string{"Bob": "Team Platform"}},
+			want: func(s string) string {
+				return strings.Replace(s, "| Alice, Bob |", "| Alice, Team Platform |", 1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			path := filepath.Join("docs", "adr", "ADR-0007-wahl-des-service-mesh.md")
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(bulkFixture), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			m := newBlankModel()
+			m.gitName, m.gitEmail = "Mallory", "m@y"
+			o := fileOption{Path: path, No: 7}
+
+			plan, err := planBulk(m, []fileOption{o}, tt.op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(plan) != 1 {
+				t.Fatalf("plan = %v, want one change", plan)
+			}
+			for _, r := range applyBulk(m, plan, tt.op) {
+				if r.err != nil {
+					t.Fatal(r.err)
+				}
+			}
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := strings.NewReplacer(
+				"| Zuletzt editiert von | Alice |", "| Zuletzt editiert von | Mallory |",
+				"| Zuletzt editiert am | 2024-03-02 |", "| Zuletzt editiert am | "+time.Now().Format("2006-01-02")+" |",
+			).Replace(tt.want(bulkFixture))
+			if string(got) != want {
+				t.Errorf("file after bulk edit:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+// Ein ADR mit eigenem Abschnitt wird nicht angefasst, statt den Abschnitt
+// beim Speichern zu verlieren.
+func TestBulkRefusesUnknownSections(t *testing.T) {
+	t.Chdir(t.TempDir())
+	path := filepath.Join("docs", "adr", "ADR-0007-wahl-des-service-mesh.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	in := strings.Replace(bulkFixture, "## Verweise", "## Notizen\nEigener Abschnitt.\n\n## Verweise", 1)
+	if err := os.WriteFile(path, []byte(in), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := newBlankModel()
+	o := fileOption{Path: path, No: 7}
+	if _, err := planBulk(m, []fileOption{o}, bulkOp{SetStatus: "Angenommen"}); err == nil || !strings.Contains(err.Error(), "Notizen") {
+		t.Errorf("planBulk: err = %v, want Notizen", err)
+	}
+	if _, err := m.editOption(o, func(e *model) { e.statusIdx = 1 }); err == nil {
+		t.Error("editOption: want error")
+	}
+	if got, _ := os.ReadFile(path); string(got) != in {
+		t.Errorf("file changed:\n%s", got)
+	}
+}
+
+func TestParseWhereQuoting(t *testing.T) {
+	tests := []struct {
+		q    string
+		want []whereTerm
+	}{
+		{`status=Vorgeschlagen tag=k8s`, []whereTerm{{"status", "=", "Vorgeschlagen"}, {"tag", "=", "k8s"}}},
+		{`titel~"service mesh"`, []whereTerm{{"titel", "~", "service mesh"}}},
+		{`beteiligte='Team Platform' "zwei wörter"`, []whereTerm{{"beteiligte", "=", "Team Platform"}, {"", "", "zwei wörter"}}},
+		{`tag!="" mesh`, []whereTerm{{"tag", "!=", ""}, {"", "", "mesh"}}},
+		{`ns=billing nr=7`, []whereTerm{{"ns", "=", "billing"}, {"nr", "=", "7"}}},
+	}
+	for _, tt := range tests {
+		got, err := parseWhere(tt.q)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.q, err)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: got %v, want %v", tt.q, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: term %d = %v, want %v", tt.q, i, got[i], tt.want[i])
+			}
+		}
+	}
+	if _, err := parseWhere(`titel~"offen`); err == nil {
+		t.Error("unterminated quote: want error")
+	}
+}
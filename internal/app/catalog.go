@@ -0,0 +1,410 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* -------------- Katalog: Entscheidungen über mehrere Repositorys ---------- */
+
+type catalogRepo struct {
+	Name  string    `json:"name"`
+	Path  string    `json:"path"` // absolute Repo-Wurzel des lokalen Klons
+	Added time.Time `json:"added"`
+}
+
+type catalogFile struct {
+	Repos []catalogRepo `json:"repos"`
+}
+
+// configHome folgt der XDG-Spezifikation ($XDG_CONFIG_HOME, sonst ~/.config).
+func configHome() (string, error) {
+	if d := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(d) {
+		return d, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config"), nil
+}
+
+func catalogPath() (string, error) {
+	dir, err := configHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "adronaut", "catalog.json"), nil
+}
+
+func loadCatalog() (catalogFile, error) {
+	var c catalogFile
+	p, err := catalogPath()
+	if err != nil {
+		return c, err
+	}
+	b, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, fmt.Errorf("%s: %w", p, err)
+	}
+	return c, nil
+}
+
+func saveCatalog(c catalogFile) error {
+	p, err := catalogPath()
+	if err != nil {
+		return err
+	}
+	sort.Slice(c.Repos, func(i, j int) bool { return c.Repos[i].Name < c.Repos[j].Name })
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicWrite(p, b, 0o644)
+}
+
+// find sucht ein Repository über Namen oder Pfad.
+func (c catalogFile) find(key string) int {
+	abs, _ := filepath.Abs(userPath(key))
+	for i, r := range c.Repos {
+		if r.Name == key || r.Path == abs {
+			return i
+		}
+	}
+	return -1
+}
+
+// catalogOptions liest die ADRs aller registrierten Repositorys. Nicht mehr
+// vorhandene Klone werden übersprungen und in missing gemeldet.
+func catalogOptions(c catalogFile) (opts []fileOption, missing []string) {
+	for _, r := range c.Repos {
+		if _, err := os.Stat(r.Path); err != nil {
+			missing = append(missing, r.Name)
+			continue
+		}
+		roots, err := repoADRRoots(r.Path)
+		if err != nil {
+			missing = append(missing, r.Name)
+			continue
+		}
+		for _, o := range scanRoots(r.Path, roots) {
+			o.Repo = r.Name
+			opts = append(opts, o)
+		}
+	}
+	return opts, missing
+}
+
+/* ----------------------------- Picker-Modus ------------------------------- */
+
+// catalogModel ist der Picker über alle Repositorys des Katalogs. Einträge
+// öffnen schreibgeschützt – bearbeitet wird im jeweiligen Repository.
+func catalogModel(c catalogFile) model {
+	opts, missing := catalogOptions(c)
+	m := newBlankModel()
+	m.catalog = true
+	m.allOptions = opts
+	m.searchDocs = buildSearchDocs(opts)
+	m.filter = newSearchField()
+	m.notice = fmt.Sprintf("Katalog: %d ADRs aus %d Repositorys", len(opts), len(c.Repos)-len(missing))
+	if len(missing) > 0 {
+		m.err = fmt.Errorf("nicht gefunden: %s", strings.Join(missing, ", "))
+	}
+	m.startup = true
+	m.applyFilter("")
+	return m
+}
+
+// catalogKeyBlocked: Schnellaktionen, Entwürfe, Historie und Mehrfachauswahl
+// gibt es im Katalog nicht.
+func (m model) catalogKeyBlocked(k string) bool {
+	switch k {
+	case "alt+s", "alt+t", "alt+d", "alt+a", "alt+x", "alt+n", "alt+b", "alt+m", "alt+e", "alt+h":
+		return true
+	case " ", "space":
+		return !m.filter.Focused()
+	}
+	return false
+}
+
+func (m model) openCatalogOption(o fileOption) (model, tea.Cmd) {
+	if err := m.loadFromFile(o.Path); err != nil {
+		m.err = fmt.Errorf("Konnte %s nicht laden: %w", o.Path, err)
+		return m, nil
+	}
+	m.readOnly = true
+	m.viewRepo = o.Repo
+	m.startup = false
+	m.step = 0
+	return m, m.focusForStep()
+}
+
+// backToCatalog schließt den angesehenen ADR; Suche und Auswahl bleiben.
+// Die Felder werden geleert, fillFromParsed überschreibt nur Vorhandenes.
+func (m model) backToCatalog() (model, tea.Cmd) {
+	for _, f := range []*textinput.Model{&m.title, &m.beteiligte, &m.tags} {
+		f.SetValue("")
+	}
+	m.kontext.SetValue("")
+	m.readOnly = false
+	m.viewRepo = ""
+	m.editingPath = ""
+	m.startup = true
+	m.step = 0
+	return m, m.filter.Focus()
+}
+
+/* --------------------------- adronaut catalog ----------------------------- */
+
+func runCatalog(args []string) error {
+	sub := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		sub, args = args[0], args[1:]
+	}
+	switch sub {
+	case "":
+		c, err := loadCatalog()
+		if err != nil {
+			return err
+		}
+		if len(c.Repos) == 0 {
+			return errors.New("der Katalog ist leer – Repositorys mit \"adronaut catalog add <pfad>\" eintragen")
+		}
+		_, err = tea.NewProgram(catalogModel(c), tea.WithAltScreen()).Run()
+		return err
+	case "add":
+		return runCatalogAdd(args)
+	case "remove", "rm":
+		return runCatalogRemove(args)
+	case "list", "ls":
+		return runCatalogList()
+	case "export":
+		return runCatalogExport(args)
+	}
+	return fmt.Errorf("catalog: unbekannter Befehl %q (add, remove, list, export)", sub)
+}
+
+func runCatalogAdd(args []string) error {
+	fs := flag.NewFlagSet("catalog add", flag.ContinueOnError)
+	name := fs.String("name", "", "Anzeigename (Standard: Verzeichnisname)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Benutzung: adronaut catalog add [--name n] <pfad> …")
+		fs.PrintDefaults()
+	}
+	paths, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return errors.New("catalog add: Pfad fehlt")
+	}
+	if *name != "" && len(paths) > 1 {
+		return errors.New("catalog add: --name nur mit einem Pfad")
+	}
+	c, err := loadCatalog()
+	if err != nil {
+		return err
+	}
+	for _, p := range paths {
+		abs, err := filepath.Abs(userPath(p))
+		if err != nil {
+			return err
+		}
+		if st, err := os.Stat(abs); err != nil || !st.IsDir() {
+			return fmt.Errorf("%s ist kein Verzeichnis", p)
+		}
+		root := findRoot(abs)
+		n := *name
+		if n == "" {
+			n = filepath.Base(root)
+		}
+		if i := c.find(root); i >= 0 {
+			fmt.Println(helpStyle.Render("bereits im Katalog: ") + c.Repos[i].Name + " (" + root + ")")
+			continue
+		}
+		for _, r := range c.Repos {
+			if r.Name == n {
+				return fmt.Errorf("Name %q ist schon vergeben (%s) – mit --name umbenennen", n, r.Path)
+			}
+		}
+		roots, err := repoADRRoots(root)
+		if err != nil {
+			return err
+		}
+		c.Repos = append(c.Repos, catalogRepo{Name: n, Path: root, Added: time.Now()})
+		fmt.Printf("%s%s (%s, %d ADRs)\n", okStyle.Render("✔ aufgenommen: "), n, root, len(scanRoots(root, roots)))
+	}
+	return saveCatalog(c)
+}
+
+func runCatalogRemove(args []string) error {
+	if len(args) == 0 {
+		return errors.New("catalog remove: Name oder Pfad fehlt")
+	}
+	c, err := loadCatalog()
+	if err != nil {
+		return err
+	}
+	for _, key := range args {
+		i := c.find(key)
+		if i < 0 {
+			return fmt.Errorf("%q ist nicht im Katalog", key)
+		}
+		fmt.Println(okStyle.Render("✔ entfernt: ") + c.Repos[i].Name)
+		c.Repos = append(c.Repos[:i], c.Repos[i+1:]...)
+	}
+	return saveCatalog(c)
+}
+
+func runCatalogList() error {
+	c, err := loadCatalog()
+	if err != nil {
+		return err
+	}
+	if len(c.Repos) == 0 {
+		fmt.Println("Der Katalog ist leer.")
+		return nil
+	}
+	for _, r := range c.Repos {
+		count := "fehlt"
+		if opts, missing := catalogOptions(catalogFile{Repos: []catalogRepo{r}}); len(missing) == 0 {
+			count = fmt.Sprintf("%d ADRs", len(opts))
+		}
+		fmt.Printf("%-20s %-10s %s\n", r.Name, count, r.Path)
+	}
+	return nil
+}
+
+// catalogEntry ist eine Zeile des gemeinsamen Exports.
+type catalogEntry struct {
+	Repo    string `json:"repo"`
+	ID      string `json:"id"`
+	Path    string `json:"path"`
+	Title   string `json:"title"`
+	Status  string `json:"status"`
+	Created string `json:"created,omitempty"`
+	Tags    string `json:"tags,omitempty"`
+
+	References []codeRef `json:"references,omitempty"` // Fundstellen im Quelltext
+}
+
+func runCatalogExport(args []string) error {
+	fs := flag.NewFlagSet("catalog export", flag.ContinueOnError)
+	format := fs.String("format", "md", "Ausgabeformat: md oder json")
+	out := fs.String("out", "", "in diese Datei statt auf die Standardausgabe schreiben")
+	full := fs.Bool("full", false, "Markdown: vollständige ADRs anhängen")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Benutzung: adronaut catalog export [--format md|json] [--full] [--out datei]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	c, err := loadCatalog()
+	if err != nil {
+		return err
+	}
+	opts, missing := catalogOptions(c)
+	for _, n := range missing {
+		fmt.Fprintln(os.Stderr, errorStyle.Render("✘ nicht gefunden: ")+n)
+	}
+	docs := buildSearchDocs(opts)
+	refs := catalogCodeRefs(c)
+
+	var b []byte
+	switch *format {
+	case "json":
+		entries := make([]catalogEntry, 0, len(opts))
+		for _, o := range opts {
+			d := docs[o.Path]
+			entries = append(entries, catalogEntry{
+				Repo: o.Repo, ID: adrID(o.NS, o.No), Path: o.Path,
+				Title: d.Title, Status: d.Status, Created: d.CreatedDate, Tags: d.Tags,
+				References: refs.of(o),
+			})
+		}
+		if b, err = json.MarshalIndent(entries, "", "  "); err != nil {
+			return err
+		}
+		b = append(b, '\n')
+	case "md":
+		b = []byte(catalogMarkdown(opts, docs, refs, *full))
+	default:
+		return fmt.Errorf("--format: unbekanntes Format %q (md, json)", *format)
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(b)
+		return err
+	}
+	return atomicWrite(userPath(*out), b, 0o644)
+}
+
+// catalogMarkdown: je Repository eine Übersichtstabelle, mit full danach die
+// ADRs selbst (Überschriften um zwei Ebenen eingerückt) samt Fundstellen im
+// Quelltext.
+func catalogMarkdown(opts []fileOption, docs map[string]searchDoc, refs codeRefIndex, full bool) string {
+	var b strings.Builder
+	b.WriteString("# Entscheidungskatalog\n\n")
+	fmt.Fprintf(&b, "Stand: %s\n", time.Now().Format("2006-01-02"))
+	repo := ""
+	for _, o := range opts {
+		if o.Repo != repo {
+			repo = o.Repo
+			fmt.Fprintf(&b, "\n## %s\n\n| Nr. | Titel | Status | Erstellt | Tags | Im Code |\n|---|---|---|---|---|---|\n", repo)
+		}
+		d := docs[o.Path]
+		cell := func(s string) string { return strings.ReplaceAll(strings.TrimSpace(s), "|", "\\|") }
+		code := ""
+		if n := len(refs.of(o)); n > 0 {
+			code = strconv.Itoa(n)
+			if staleStatus(d.Status) {
+				code = "⚠ " + code
+			}
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n", adrID(o.NS, o.No), cell(d.Title), cell(d.Status), cell(d.CreatedDate), cell(d.Tags), code)
+	}
+	if !full {
+		return b.String()
+	}
+	for _, o := range opts {
+		txt, err := os.ReadFile(o.Path)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "\n---\n\n<!-- %s: %s -->\n\n", o.Repo, adrID(o.NS, o.No))
+		fence := false
+		for _, line := range strings.Split(strings.TrimRight(string(txt), "\n"), "\n") {
+			if strings.HasPrefix(line, "```") {
+				fence = !fence
+			}
+			if !fence && strings.HasPrefix(line, "#") {
+				line = "##" + line
+			}
+			b.WriteString(line + "\n")
+		}
+		if rs := refs.of(o); len(rs) > 0 {
+			b.WriteString("\n**Im Code zitiert:**\n\n")
+			for _, r := range rs {
+				fmt.Fprintf(&b, "- `%s`\n", r)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+/* --------------------------- Kommandozeile -------------------------------- */
+
+var commands = map[string]func(args []string) error{
+	"bulk":     runBulk,
+	"catalog":  runCatalog,
+	"drafts":   runDrafts,
+	"refs":     runRefs,
+	"rename":   runRename,
+	"renumber": runRenumber,
+	"reserve":  runReserve,
+	"restore":  runRestore,
+	"schema":   runSchema,
+	"verify":   runVerify,
+}
+
+// headlessModel liefert ein Modell mit Picker-Daten und Git-Angaben, wie es
+// die TUI beim Start aufbaut – nur ohne Terminal.
+func headlessModel() model {
+	m := initialModel()
+	if gi, ok := loadGitInfoCmd()().(gitInfoLoadedMsg); ok {
+		m.gitName, m.gitEmail, m.gitSigningKey = gi.name, gi.email, gi.signingKey
+	}
+	return m
+}
+
+// queryOptions filtert alle ADRs und Entwürfe mit einem --where-Ausdruck.
+func (m model) queryOptions(where string) ([]fileOption, error) {
+	terms, err := parseWhere(where)
+	if err != nil {
+		return nil, err
+	}
+	var out []fileOption
+	for _, o := range m.allOptions {
+		if o.Path == newAdrSentinel {
+			continue
+		}
+		if whereMatches(terms, o, m.searchDocs[o.Path]) {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
+
+func runBulk(args []string) error {
+	fs := flag.NewFlagSet("bulk", flag.ContinueOnError)
+	where := fs.String("where", "", "Auswahl, z. B. 'status=Vorgeschlagen tag=sicherheit'")
+	status := fs.String("status", "", "neuen Status setzen")
+	addTag := fs.String("add-tag", "", "Tags hinzufügen (Komma-getrennt)")
+	rmTag := fs.String("remove-tag", "", "Tags entfernen (Komma-getrennt)")
+	renTag := fs.String("rename-tag", "", "Tags umbenennen (alt=neu, …)")
+	renPart := fs.String("rename-beteiligte", "", "Beteiligte umbenennen (alt=neu, …)")
+	yes := fs.Bool("yes", false, "Änderungen ohne Rückfrage schreiben")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Benutzung: adronaut bulk --where '<ausdruck>' [Änderungen] [--yes]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if strings.TrimSpace(*where) == "" {
+		return errors.New("bulk: --where fehlt")
+	}
+
+	op := bulkOp{AddTags: splitCSV(*addTag), RemoveTags: splitCSV(*rmTag)}
+	if *status != "" {
+		if statusRank(*status) == len(statuses) {
+			return fmt.Errorf("unbekannter Status %q (erlaubt: %s)", *status, strings.Join(statuses, ", "))
+		}
+		op.SetStatus = statuses[statusRank(*status)]
+	}
+	var err error
+	if op.RenameTags, err = parseRenames(*renTag); err != nil {
+		return err
+	}
+	if op.RenameParticipants, err = parseRenames(*renPart); err != nil {
+		return err
+	}
+
+	m := headlessModel()
+	targets, err := m.queryOptions(*where)
+	if err != nil {
+		return err
+	}
+	if op.empty() {
+		for _, o := range targets {
+			fmt.Println(o.Path)
+		}
+		return nil
+	}
+
+	plan, err := planBulk(m, targets, op)
+	if err != nil {
+		return err
+	}
+	fmt.Println(labelStyle.Render("Vorschau: " + op.String()))
+	fmt.Println(formatBulkPlan(plan, 0))
+	if !*yes || len(plan) == 0 {
+		if len(plan) > 0 {
+			fmt.Println(helpStyle.Render("Mit --yes anwenden."))
+		}
+		return nil
+	}
+	failed := 0
+	for _, r := range applyBulk(m, plan, op) {
+		if r.err != nil {
+			failed++
+			fmt.Fprintln(os.Stderr, errorStyle.Render("✘ ")+r.oldPath+": "+r.err.Error())
+			continue
+		}
+		fmt.Println(okStyle.Render("✔ ") + r.newPath)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d Datei(en) konnten nicht geschrieben werden", failed)
+	}
+	return nil
+}
+
+// parseArgs erlaubt Optionen auch nach den Argumenten
+// (adronaut renumber datei.md --to 7) und liefert die Argumente.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return pos, nil
+		}
+		pos = append(pos, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
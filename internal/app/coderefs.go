@@ -0,0 +1,313 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* -------------- Rückverweise: wo der Quelltext ADRs zitiert --------------- */
+
+// codeRef ist eine Kommentarzeile im Quelltext, die einen ADR nennt.
+type codeRef struct {
+	Path string `json:"path"` // relativ zur Repo-Wurzel
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+func (r codeRef) String() string { return fmt.Sprintf("%s:%d", filepath.ToSlash(r.Path), r.Line) }
+
+// codeRefRe findet "ADR-0012", "ADR 12" oder "billing/ADR-0003".
+var codeRefRe = regexp.MustCompile(`(?:\b([\w.-]+)/)?\bADR[- ]?([0-9]+)\b`)
+
+// codeExts: nur diese Dateien werden durchsucht.
+var codeExts = map[string]bool{
+	".go": true, ".ts": true, ".tsx": true, ".js": true, ".jsx": true, ".mjs": true,
+	".py": true, ".java": true, ".kt": true, ".scala": true, ".rs": true, ".rb": true,
+	".cs": true, ".c": true, ".h": true, ".cc": true, ".cpp": true, ".hpp": true,
+	".swift": true, ".php": true, ".sh": true, ".sql": true, ".proto": true,
+	".tf": true, ".yaml": true, ".yml": true, ".toml": true,
+}
+
+const codeRefMaxSize = 1 << 20 // größere Dateien sind meist generiert
+
+// codeRefIndex ordnet Fundstellen einem ADR zu; der Schlüssel ist refKey.
+type codeRefIndex map[string][]codeRef
+
+func refKey(repo, id string) string {
+	if repo == "" {
+		return id
+	}
+	return repo + ":" + id
+}
+
+func (x codeRefIndex) of(o fileOption) []codeRef {
+	if o.No <= 0 || o.Draft {
+		return nil
+	}
+	return x[refKey(o.Repo, adrID(o.NS, o.No))]
+}
+
+// scan durchsucht die Kommentare aller Quelltexte unterhalb von base. Ein
+// ADR ohne Namensraum gehört zu dem ADR-Verzeichnis des Teilbaums, in dem
+// die Datei liegt (wie bei neuen ADRs).
+func (x codeRefIndex) scan(repo, base string, roots []adrRoot) {
+	known := map[string]bool{}
+	for _, r := range roots {
+		if r.NS != "" {
+			known[r.NS] = true
+		}
+	}
+	walkRepo(base, func(p, rel string, d fs.DirEntry) {
+		if !codeExts[strings.ToLower(filepath.Ext(p))] {
+			return
+		}
+		if fi, err := d.Info(); err != nil || fi.Size() > codeRefMaxSize {
+			return
+		}
+		home := ownerRoot(roots, rel).NS
+		f, err := os.Open(p)
+		if err != nil {
+			return
+		}
+		defer f.Close()
+		sc := bufio.NewScanner(f)
+		sc.Buffer(make([]byte, 64*1024), codeRefMaxSize)
+		for n := 1; sc.Scan(); n++ {
+			line := sc.Text()
+			c := commentStart(line)
+			if c < 0 {
+				continue
+			}
+			seen := map[string]bool{}
+			for _, mm := range codeRefRe.FindAllStringSubmatch(line[c:], -1) {
+				no, err := strconv.Atoi(mm[2])
+				if err != nil {
+					continue
+				}
+				ns := mm[1]
+				if !known[ns] {
+					ns = home // z. B. ein Pfad wie docs/adr/ADR-0001-….md
+				}
+				id := adrID(ns, no)
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+				k := refKey(repo, id)
+				x[k] = append(x[k], codeRef{Path: rel, Line: n, Text: strings.TrimSpace(line)})
+			}
+		}
+	})
+}
+
+// walkRepo ruft fn für jede Datei unterhalb von base auf; versteckte
+// Verzeichnisse und skipDirs werden ausgelassen, rel ist relativ zu base.
+func walkRepo(base string, fn func(p, rel string, d fs.DirEntry)) {
+	_ = filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if p != base && (strings.HasPrefix(d.Name(), ".") || skipDirs[d.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if rel, err := filepath.Rel(base, p); err == nil {
+			fn(p, rel, d)
+		}
+		return nil
+	})
+}
+
+// commentStart liefert den Beginn des Kommentars in line, sonst -1.
+func commentStart(line string) int {
+	if strings.HasPrefix(strings.TrimSpace(line), "*") {
+		return 0 // Fortsetzung eines Blockkommentars
+	}
+	best := -1
+	for _, mk := range []string{"//", "/*", "#", "--"} {
+		if i := strings.Index(line, mk); i >= 0 && (best < 0 || i < best) {
+			best = i
+		}
+	}
+	return best
+}
+
+// staleStatus: ADRs mit diesem Status sollte der Code nicht mehr zitieren.
+func staleStatus(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.EqualFold(s, "Veraltet") || strings.EqualFold(s, "Abgelehnt")
+}
+
+type codeRefsLoadedMsg struct{ refs codeRefIndex }
+
+// loadCodeRefsCmd baut den Index im Hintergrund – im Katalog über alle
+// registrierten Repositorys.
+func loadCodeRefsCmd(catalog bool) tea.Cmd {
+	return func() tea.Msg {
+		x := codeRefIndex{}
+		if !catalog {
+			x.scan("", ".", adrRoots)
+			return codeRefsLoadedMsg{x}
+		}
+		c, _ := loadCatalog()
+		return codeRefsLoadedMsg{catalogCodeRefs(c)}
+	}
+}
+
+func catalogCodeRefs(c catalogFile) codeRefIndex {
+	x := codeRefIndex{}
+	for _, r := range c.Repos {
+		if roots, err := repoADRRoots(r.Path); err == nil {
+			x.scan(r.Name, r.Path, roots)
+		}
+	}
+	return x
+}
+
+/* ----------------------------- adronaut refs ------------------------------ */
+
+// refsEntry ist ein ADR mit seinen Fundstellen (für --format json).
+type refsEntry struct {
+	ID         string    `json:"id"`
+	Path       string    `json:"path,omitempty"` // leer: ADR existiert nicht
+	Title      string    `json:"title,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Stale      bool      `json:"stale,omitempty"`
+	References []codeRef `json:"references"`
+}
+
+func runRefs(args []string) error {
+	fs := flag.NewFlagSet("refs", flag.ContinueOnError)
+	format := fs.String("format", "text", "Ausgabeformat: text oder json")
+	strict := fs.Bool("strict", false, "mit Fehler beenden, wenn Code veraltete, abgelehnte oder unbekannte ADRs zitiert")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Benutzung: adronaut refs [adr] [--format text|json] [--strict]")
+		fs.PrintDefaults()
+	}
+	pos, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("--format: unbekanntes Format %q (text, json)", *format)
+	}
+	filter := ""
+	if len(pos) > 0 {
+		filter = pos[0]
+	}
+
+	x := codeRefIndex{}
+	x.scan("", ".", adrRoots)
+	opts := scanAllADRFiles()
+	docs := buildSearchDocs(opts)
+
+	var entries []refsEntry
+	cited := map[string]bool{}
+	for _, o := range opts {
+		id := adrID(o.NS, o.No)
+		cited[id] = true
+		refs := x.of(o)
+		if filter != "" && !matchesADR(o, filter) || filter == "" && len(refs) == 0 {
+			continue
+		}
+		d := docs[o.Path]
+		entries = append(entries, refsEntry{
+			ID: id, Path: o.Path, Title: d.Title, Status: d.Status,
+			Stale: len(refs) > 0 && staleStatus(d.Status), References: refs,
+		})
+	}
+	if filter == "" {
+		var unknown []string
+		for id := range x {
+			if !cited[id] {
+				unknown = append(unknown, id)
+			}
+		}
+		sort.Strings(unknown)
+		for _, id := range unknown {
+			entries = append(entries, refsEntry{ID: id, Stale: true, References: x[id]})
+		}
+	} else if len(entries) == 0 {
+		return fmt.Errorf("kein ADR %q gefunden", filter)
+	}
+
+	warnings := 0
+	for _, e := range entries {
+		if e.Stale {
+			warnings++
+		}
+	}
+	if *format == "json" {
+		if entries == nil {
+			entries = []refsEntry{}
+		}
+		b, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	} else {
+		printRefs(entries)
+	}
+	if *strict && warnings > 0 {
+		return fmt.Errorf("%d ADR(s) werden im Code zitiert, obwohl veraltet, abgelehnt oder unbekannt", warnings)
+	}
+	return nil
+}
+
+func printRefs(entries []refsEntry) {
+	if len(entries) == 0 {
+		fmt.Println("Kein ADR wird im Code zitiert.")
+		return
+	}
+	places := 0
+	for _, e := range entries {
+		head := labelStyle.Render(e.ID)
+		switch {
+		case e.Path == "":
+			head = errorStyle.Render("⚠ "+e.ID) + " – unbekannter ADR"
+		case e.Stale:
+			head = errorStyle.Render("⚠ "+e.ID) + " " + e.Title + " – " + e.Status + ", wird aber noch zitiert"
+		default:
+			head += " " + e.Title
+			if e.Status != "" {
+				head += helpStyle.Render(" (" + e.Status + ")")
+			}
+		}
+		fmt.Println(head)
+		if len(e.References) == 0 {
+			fmt.Println(helpStyle.Render("  (nicht im Code zitiert)"))
+		}
+		for _, r := range e.References {
+			fmt.Printf("  %s  %s\n", r, helpStyle.Render(r.Text))
+		}
+		places += len(e.References)
+	}
+	fmt.Println(helpStyle.Render(fmt.Sprintf("%d ADR(s), %d Fundstelle(n)", len(entries), places)))
+}
+
+// matchesADR: Datei (relativ zum Aufrufort), Kennung oder Nummer – eine
+// Nummer ohne Namensraum gilt im Teilbaum des Aufrufs.
+func matchesADR(o fileOption, arg string) bool {
+	if filepath.Clean(userPath(arg)) == filepath.Clean(o.Path) {
+		return true
+	}
+	if strings.EqualFold(arg, adrID(o.NS, o.No)) || arg == o.number() {
+		return true
+	}
+	n, err := strconv.Atoi(arg)
+	return err == nil && o.NS == defaultNewRoot().NS && n == o.No
+}
@@ -0,0 +1,275 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+/* ------------ Externe Änderungen erkennen + Drei-Wege-Merge --------------- */
+
+// conflictError meldet, dass die Zieldatei seit dem Laden verändert wurde.
+type conflictError struct {
+	path    string
+	theirs  string // aktueller Inhalt auf der Platte; leer, wenn gelöscht
+	deleted bool
+	modTime time.Time
+}
+
+func (e *conflictError) Error() string {
+	if e.deleted {
+		return fmt.Sprintf("%s wurde seit dem Öffnen gelöscht", e.path)
+	}
+	return fmt.Sprintf("%s wurde seit dem Öffnen extern geändert (%s)", e.path, e.modTime.Format("15:04:05"))
+}
+
+func contentHash(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+// rememberBase merkt sich den geladenen Stand einer Datei als Merge-Basis.
+func (m *model) rememberBase(path, content string) {
+	m.baseContent = content
+	m.baseHash = contentHash(content)
+	if st, err := os.Stat(path); err == nil {
+		m.baseModTime = st.ModTime()
+	}
+}
+
+// checkExternal vergleicht die Datei auf der Platte mit der Merge-Basis.
+// Die mtime dient nur als schneller Vorab-Test, entscheidend ist der Hash.
+func checkExternal(m model) error {
+	if m.editingPath == "" || m.baseHash == "" {
+		return nil
+	}
+	st, err := os.Stat(m.editingPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return &conflictError{path: m.editingPath, deleted: true}
+	}
+	if err != nil {
+		return err
+	}
+	if st.ModTime().Equal(m.baseModTime) {
+		return nil
+	}
+	b, err := os.ReadFile(m.editingPath)
+	if err != nil {
+		return err
+	}
+	if contentHash(string(b)) == m.baseHash {
+		return nil // nur angefasst, Inhalt gleich
+	}
+	return &conflictError{path: m.editingPath, theirs: string(b), modTime: st.ModTime()}
+}
+
+/* ------------------------------- Merge ------------------------------------ */
+
+type mergeState int
+
+const (
+	mergeSame     mergeState = iota // beide gleich
+	mergeOurs                       // nur lokal geändert
+	mergeTheirs                     // nur extern geändert
+	mergeConflict                   // beide unterschiedlich geändert
+)
+
+type mergeSection struct {
+	name               string
+	base, theirs, ours string
+	state              mergeState
+	choice             string // "b", "t" oder "o"
+}
+
+func (s mergeSection) resolved() string {
+	switch s.choice {
+	case "b":
+		return s.base
+	case "t":
+		return s.theirs
+	}
+	return s.ours
+}
+
+type mergeView struct {
+	path    string
+	theirs  string
+	deleted bool
+	secs    []mergeSection
+	idx     int
+}
+
+var blankLinesRe = regexp.MustCompile(`\n\s*\n+`)
+
+func normSection(s string) string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+	if strings.EqualFold(s, "(noch offen)") || strings.EqualFold(s, "(keine oder noch offen)") {
+		return ""
+	}
+	return blankLinesRe.ReplaceAllString(s, "\n\n")
+}
+
+// sectionValues liefert die vergleichbaren Abschnitte eines ADR.
+func sectionValues(p parsedADR) []string {
+	return []string{p.Title, p.Status, p.Kontext, p.Entscheidung, p.Alternativen, p.Konsequenzen, p.Beteiligte, p.Tags, p.Pruefregeln, p.Verweise}
+}
+
+var mergeSectionNames = []string{"Titel", "Status", "Kontext", "Entscheidung", "Alternativen", "Konsequenzen", "Beteiligte", "Tags", rulesHeading, "Verweise"}
+
+func (m model) oursParsed() parsedADR {
+	return parsedADR{
+		Title: m.Title(), Status: m.Status(), Kontext: m.Kontext(),
+		Entscheidung: m.Entscheidung(), Alternativen: m.Alternativen(), Konsequenzen: m.Konsequenzen(),
+		Beteiligte: m.Beteiligte(), Tags: m.Tags(), Pruefregeln: m.pruefregeln,
+		Verweise: m.verweise,
+	}
+}
+
+func buildMerge(m model, ce *conflictError) *mergeView {
+	base := sectionValues(parseADRText(m.editingPath, m.baseContent))
+	theirs := make([]string, len(base))
+	if !ce.deleted {
+		theirs = sectionValues(parseADRText(ce.path, ce.theirs))
+	}
+	ours := sectionValues(m.oursParsed())
+
+	mv := &mergeView{path: ce.path, theirs: ce.theirs, deleted: ce.deleted}
+	for i, name := range mergeSectionNames {
+		s := mergeSection{name: name, base: normSection(base[i]), theirs: normSection(theirs[i]), ours: normSection(ours[i]), choice: "o"}
+		switch {
+		case s.theirs == s.ours:
+			s.state = mergeSame
+		case s.base == s.theirs:
+			s.state = mergeOurs
+		case s.base == s.ours:
+			s.state, s.choice = mergeTheirs, "t"
+		default:
+			s.state = mergeConflict
+		}
+		mv.secs = append(mv.secs, s)
+	}
+	return mv
+}
+
+// applyMerge übernimmt die gewählten Abschnitte ins Modell und setzt die
+// Basis auf den externen Stand, damit der nächste Speicherversuch durchgeht.
+func (m *model) applyMerge() {
+	w := m.kontext.Width()
+	for _, s := range m.merge.secs {
+		v := s.resolved()
+		if v == s.ours {
+			continue // eigener Stand bleibt wörtlich, nicht normalisiert
+		}
+		switch s.name {
+		case "Titel":
+			m.title.SetValue(v)
+		case "Status":
+			m.statusIdx = statusRank(v) % len(statuses)
+		case "Kontext":
+			m.kontext.SetValue(v)
+		case "Entscheidung":
+			m.entscheidung.SetFromMarkdown(v, 5, w)
+		case "Alternativen":
+			m.alternativen.SetFromMarkdown(v, 5, w)
+		case "Konsequenzen":
+			m.konsequenzen.SetFromMarkdown(v, 5, w)
+		case "Beteiligte":
+			m.beteiligte.SetValue(v)
+		case "Tags":
+			m.tags.SetValue(v)
+		case rulesHeading:
+			m.pruefregeln = v
+		case "Verweise":
+			m.verweise = v
+		}
+	}
+	if m.merge.deleted {
+		m.baseHash = "" // Datei neu anlegen
+	} else {
+		m.baseContent = m.merge.theirs
+		m.baseHash = contentHash(m.merge.theirs)
+	}
+	m.merge = nil
+}
+
+func (m model) updateMerge(msg tea.KeyMsg) (model, tea.Cmd) {
+	mv := m.merge
+	switch strings.ToLower(msg.String()) {
+	case "up", "ctrl+p", "shift+tab":
+		mv.idx = (mv.idx + len(mv.secs) - 1) % len(mv.secs)
+	case "down", "ctrl+n", "tab":
+		mv.idx = (mv.idx + 1) % len(mv.secs)
+	case "b", "t", "o":
+		mv.secs[mv.idx].choice = strings.ToLower(msg.String())
+	case "s", "enter":
+		m.applyMerge()
+		m.err = nil
+		m.saving = true
+		return m, saveCmd(m)
+	case "esc":
+		m.merge = nil
+		m.confirming = false
+	}
+	return m, nil
+}
+
+func (m model) viewMerge() string {
+	mv := m.merge
+	var b strings.Builder
+	b.WriteString(errorStyle.Render("Konflikt: ") + mv.path)
+	if mv.deleted {
+		b.WriteString(" wurde gelöscht – Speichern legt die Datei neu an.")
+	} else {
+		b.WriteString(" wurde extern geändert.")
+	}
+	b.WriteString("\n\n")
+
+	stateLabel := map[mergeState]string{
+		mergeSame:     "unverändert/gleich",
+		mergeOurs:     "nur lokal geändert",
+		mergeTheirs:   "nur extern geändert",
+		mergeConflict: "beidseitig geändert",
+	}
+	choiceLabel := map[string]string{"b": "Basis", "t": "extern", "o": "lokal"}
+	for i, s := range mv.secs {
+		st := optionStyle
+		if i == mv.idx {
+			st = selectedStyle
+		}
+		line := fmt.Sprintf("%-13s %-20s → %s", s.name, stateLabel[s.state], choiceLabel[s.choice])
+		if s.state == mergeConflict {
+			line = "⚡ " + line
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(st.Render(line) + "\n")
+	}
+
+	cur := mv.secs[mv.idx]
+	colW := max(20, (m.width-2*framePadding)/3-2)
+	col := func(title, txt string, active bool) string {
+		style := lipgloss.NewStyle().Width(colW).Border(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color(gbGray))
+		if active {
+			style = style.BorderForeground(lipgloss.Color(gbYellow))
+		}
+		if txt == "" {
+			txt = helpStyle.Render("(leer)")
+		}
+		return style.Render(labelStyle.Render(title) + "\n" + txt)
+	}
+	b.WriteString("\n" + lipgloss.JoinHorizontal(lipgloss.Top,
+		col("Basis (geladen)", cur.base, cur.choice == "b"),
+		col("Extern (Platte)", cur.theirs, cur.choice == "t"),
+		col("Lokal (Editor)", cur.ours, cur.choice == "o"),
+	))
+	b.WriteString("\n" + m.help("↑/↓ Abschnitt · B Basis · T extern · O lokal übernehmen · S/ENTER zusammenführen und speichern · ESC abbrechen"))
+	return b.String()
+}
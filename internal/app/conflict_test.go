@@ -0,0 +1,91 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func adrWithRules(rules, tags string) string {
+	return "# ADR 0001: A\n\n| Feld | Wert |\n|------|------|\n| Status | Angenommen |\n| Tags | " + tags + " |\n\n" +
+		"## Kontext\nk\n\n## Entscheidung\n1. e\n\n## Alternativen\n(keine oder noch offen)\n\n## Konsequenzen\n(noch offen)\n\n" +
+		"## " + rulesHeading + "\n" + rules + "\n"
+}
+
+// Eine extern geänderte Prüfregel geht beim Zusammenführen nicht verloren,
+// die eigene bleibt unverändert.
+func TestMergeKeepsRules(t *testing.T) {
+	const path = "ADR-0001-a.md"
+	base := adrWithRules("- verbietet: a", "x")
+	tests := []struct {
+		name, ours, theirs, want string
+	}{
+		{"extern geändert", "- verbietet: a", "- verbietet: b", "- verbietet: b"},
+		{"selbst geändert", "- verbietet: c", "- verbietet: a", "- verbietet: c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newBlankModel()
+			m.editingPath = path
+			m.fillFromParsed(parseADRText(path, base))
+			m.rememberBase(path, base)
+			m.pruefregeln = tt.ours
+			m.tags.SetValue("x, eigen")
+
+			m.merge = buildMerge(m, &conflictError{path: path, theirs: adrWithRules(tt.theirs, "x")})
+			var rules *mergeSection
+			for i := range m.merge.secs {
+				if m.merge.secs[i].name == rulesHeading {
+					rules = &m.merge.secs[i]
+				}
+			}
+			if rules == nil {
+				t.Fatal("no Prüfregeln section in merge")
+			}
+			if rules.state == mergeConflict || rules.state == mergeSame {
+				t.Errorf("state = %v", rules.state)
+			}
+			m.applyMerge()
+			if m.pruefregeln != tt.want {
+				t.Errorf("pruefregeln = %q, want %q", m.pruefregeln, tt.want)
+			}
+			if m.Tags() != "x, eigen" {
+				t.Errorf("tags = %q", m.Tags())
+			}
+		})
+	}
+}
+
+func TestDraftSectionsIncludeRules(t *testing.T) {
+	a := draftFile{Title: "A", Pruefregeln: "- verbietet: a"}
+	b := a
+	b.Pruefregeln = "- verbietet: b"
+	if got := changedSections(a, b); strings.Join(got, ",") != rulesHeading {
+		t.Errorf("changedSections = %v, want [%s]", got, rulesHeading)
+	}
+}
+
+// Beim Zusammenführen bleiben eigene Abschnitte wörtlich erhalten, auch wenn
+// der Vergleich sie normalisiert.
+func TestMergeKeepsOwnTextRaw(t *testing.T) {
+	const path = "ADR-0001-a.md"
+	base := adrWithRules("- verbietet: a", "x")
+	m := newBlankModel()
+	m.editingPath = path
+	m.fillFromParsed(parseADRText(path, base))
+	m.rememberBase(path, base)
+	const kontext = "erster Absatz\n\n\n\nzweiter Absatz"
+	m.kontext.SetValue(kontext)
+	m.verweise = "- [B](ADR-0002-b.md)\n\n\n- [C](ADR-0003-c.md)"
+
+	m.merge = buildMerge(m, &conflictError{path: path, theirs: adrWithRules("- verbietet: a", "y")})
+	m.applyMerge()
+	if got := m.kontext.Value(); got != kontext {
+		t.Errorf("kontext = %q, want %q", got, kontext)
+	}
+	if got := m.verweise; got != "- [B](ADR-0002-b.md)\n\n\n- [C](ADR-0003-c.md)" {
+		t.Errorf("verweise = %q", got)
+	}
+	if m.Tags() != "y" {
+		t.Errorf("tags = %q, want y", m.Tags())
+	}
+}
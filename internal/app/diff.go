@@ -0,0 +1,175 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+/* --------------------------- Zeilen-Diff (LCS) ---------------------------- */
+
+type diffOp byte
+
+const (
+	diffSame diffOp = '='
+	diffDel  diffOp = '-'
+	diffAdd  diffOp = '+'
+)
+
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+// lineDiff vergleicht a und b zeilenweise über die längste gemeinsame
+// Teilfolge. ADR-Abschnitte sind kurz, O(n·m) reicht völlig.
+func lineDiff(a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var out []diffLine
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, diffLine{diffSame, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, diffLine{diffDel, a[i]})
+			i++
+		default:
+			out = append(out, diffLine{diffAdd, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		out = append(out, diffLine{diffDel, a[i]})
+	}
+	for ; j < m; j++ {
+		out = append(out, diffLine{diffAdd, b[j]})
+	}
+	return out
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
+
+func diffChanged(d []diffLine) bool {
+	for _, l := range d {
+		if l.op != diffSame {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	diffDelStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color(gbRed))
+	diffAddStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color(gbGreen))
+	diffHunkStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(gbBlue))
+)
+
+// diffKeep markiert die Zeilen, die mit ctx Zeilen Kontext gezeigt werden.
+func diffKeep(d []diffLine, ctx int) []bool {
+	keep := make([]bool, len(d))
+	for i, l := range d {
+		if l.op == diffSame {
+			continue
+		}
+		for k := max(0, i-ctx); k <= min(len(d)-1, i+ctx); k++ {
+			keep[k] = true
+		}
+	}
+	return keep
+}
+
+func (l diffLine) render() string {
+	switch l.op {
+	case diffDel:
+		return diffDelStyle.Render("- " + l.text)
+	case diffAdd:
+		return diffAddStyle.Render("+ " + l.text)
+	}
+	return "  " + l.text
+}
+
+// renderDiff zeigt Änderungen mit ctx Zeilen Kontext; längere unveränderte
+// Strecken werden zu "…" zusammengefasst.
+func renderDiff(d []diffLine, ctx int) string {
+	keep := diffKeep(d, ctx)
+	var b strings.Builder
+	skipped := false
+	for i, l := range d {
+		if !keep[i] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			b.WriteString(helpStyle.Render("  …") + "\n")
+			skipped = false
+		}
+		b.WriteString(l.render() + "\n")
+	}
+	if skipped {
+		b.WriteString(helpStyle.Render("  …") + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// renderSectionDiff ist renderDiff für ganze ADRs: jeder Änderungsblock
+// beginnt mit dem Abschnitt, in dem er liegt ("@@ Kontext @@").
+func renderSectionDiff(d []diffLine, ctx int) string {
+	section := make([]string, len(d))
+	cur := "Kopf"
+	for i, l := range d {
+		if h, ok := strings.CutPrefix(l.text, "## "); ok && l.op != diffDel {
+			cur = strings.TrimSpace(h)
+		}
+		section[i] = cur
+	}
+	keep := diffKeep(d, ctx)
+	var b strings.Builder
+	for i, l := range d {
+		if !keep[i] {
+			continue
+		}
+		if i == 0 || !keep[i-1] {
+			j := i
+			for j < len(d)-1 && keep[j+1] && d[j].op == diffSame {
+				j++
+			}
+			b.WriteString(diffHunkStyle.Render("@@ "+section[j]+" @@") + "\n")
+		}
+		b.WriteString(l.render() + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// diffStat zählt hinzugefügte und entfernte Zeilen.
+func diffStat(d []diffLine) (add, del int) {
+	for _, l := range d {
+		switch l.op {
+		case diffAdd:
+			add++
+		case diffDel:
+			del++
+		}
+	}
+	return add, del
+}
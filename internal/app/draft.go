@@ -1,7 +1,6 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/fs"
 	"os"
@@ -11,6 +10,8 @@ import (
 )
 
 type draftFile struct {
+	SchemaVersion int `json:"schema_version"`
+
 	EditingPath  string    `json:"editing_path"`
 	EditingNo    int       `json:"editing_no"`
 	Title        string    `json:"title"`
@@ -23,15 +24,29 @@ type draftFile struct {
 	Tags         string    `json:"tags"`
 	SavedAt      time.Time `json:"saved_at"`
 	CreatedDate  string    `json:"created_date"`
+	Pruefregeln  string    `json:"pruefregeln,omitempty"`
+	Verweise     string    `json:"verweise,omitempty"`
+	Autor        string    `json:"autor,omitempty"`
+	SigningKey   string    `json:"signing_key,omitempty"`
+
+	// Stand der Datei beim Öffnen, damit externe Änderungen auch nach einem
+	// Neustart erkannt werden.
+	BaseHash    string    `json:"base_hash,omitempty"`
+	BaseModTime time.Time `json:"base_mtime,omitempty"`
+	BaseContent string    `json:"base_content,omitempty"`
 }
 
 func (m model) draftPath() string {
-	_ = os.MkdirAll(autosaveDir, 0o755)
+	if autosaveDir == metaDir {
+		_, _ = ensureMetaDir("")
+	} else {
+		_ = os.MkdirAll(autosaveDir, 0o755)
+	}
 	if m.draftFixedPath != "" { // fester Pfad (neuer ADR oder aus Draft)
 		return m.draftFixedPath
 	}
 	if m.editingPath != "" { // bestehende Datei
-		return filepath.Join(autosaveDir, filepath.Base(m.editingPath)+".draft.json")
+		return filepath.Join(autosaveDir, draftName(m.editingPath))
 	}
 	// Fallback (sollte selten greifen)
 	t := strings.TrimSpace(m.title.Value())
@@ -42,32 +57,69 @@ func (m model) draftPath() string {
 	return filepath.Join(autosaveDir, fmt.Sprintf("new-%s.draft.json", name))
 }
 
+// draftName ist der Name des Entwurfs zu einer ADR-Datei. Im
+// Hauptverzeichnis genügt der Dateiname, sonst steht der Pfad davor, damit
+// gleichnamige ADRs verschiedener Teilbäume sich weder Entwurf noch Sperre
+// teilen.
+func draftName(adrPath string) string {
+	name := filepath.Base(adrPath) + ".draft.json"
+	if r, ok := rootFor(adrPath); ok && r.NS == "" {
+		return name
+	}
+	dir := filepath.ToSlash(filepath.Clean(filepath.Dir(adrPath)))
+	return strings.ReplaceAll(dir, "/", "--") + "--" + name
+}
+
+// newDraftPath liefert einen eindeutigen Entwurfspfad für einen neuen ADR.
+func newDraftPath() string {
+	return filepath.Join(autosaveDir, fmt.Sprintf("new-%d.draft.json", time.Now().UnixNano()))
+}
+
 func (m model) toDraft() draftFile {
 	return draftFile{
-		EditingPath:  m.editingPath,
-		EditingNo:    m.editingNo,
-		Title:        m.title.Value(),
-		StatusIdx:    m.statusIdx,
-		Kontext:      m.kontext.Value(),
-		Entscheidung: m.entscheidung.Values(),
-		Konsequenzen: m.konsequenzen.Values(),
-		Alternativen: m.alternativen.Values(),
-		Beteiligte:   m.beteiligte.Value(),
-		Tags:         m.tags.Value(),
-		SavedAt:      time.Now(),
-		CreatedDate:  m.createdDate,
+		SchemaVersion: draftSchemaVersion,
+		EditingPath:   m.editingPath,
+		EditingNo:     m.editingNo,
+		Title:         m.title.Value(),
+		StatusIdx:     m.statusIdx,
+		Kontext:       m.kontext.Value(),
+		Entscheidung:  m.entscheidung.Values(),
+		Konsequenzen:  m.konsequenzen.Values(),
+		Alternativen:  m.alternativen.Values(),
+		Beteiligte:    m.beteiligte.Value(),
+		Tags:          m.tags.Value(),
+		SavedAt:       time.Now(),
+		CreatedDate:   m.createdDate,
+		Pruefregeln:   m.pruefregeln,
+		Verweise:      m.verweise,
+		Autor:         m.autor,
+		SigningKey:    m.signingKey,
+		BaseHash:      m.baseHash,
+		BaseModTime:   m.baseModTime,
+		BaseContent:   m.baseContent,
 	}
 }
 
 func (m *model) loadDraft(path string) error {
-	b, err := os.ReadFile(path)
+	d, err := readDraftFile(path)
 	if err != nil {
 		return err
 	}
-	var d draftFile
-	if err := json.Unmarshal(b, &d); err != nil {
-		return err
+	m.applyDraft(d)
+	return nil
+}
+
+// readDraftFile liest einen Entwurf und hebt ältere Formate auf die
+// aktuelle Schema-Version an (siehe decodeDraft).
+func readDraftFile(path string) (draftFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return draftFile{}, err
 	}
+	return decodeDraft(b)
+}
+
+func (m *model) applyDraft(d draftFile) {
 	m.editingPath = d.EditingPath
 	m.editingNo = d.EditingNo
 	m.title.SetValue(d.Title)
@@ -84,7 +136,12 @@ func (m *model) loadDraft(path string) error {
 	m.beteiligte.SetValue(d.Beteiligte)
 	m.tags.SetValue(d.Tags)
 	m.createdDate = d.CreatedDate
-	return nil
+	m.pruefregeln = d.Pruefregeln
+	m.verweise = d.Verweise
+	m.autor, m.signingKey = d.Autor, d.SigningKey
+	m.baseHash = d.BaseHash
+	m.baseModTime = d.BaseModTime
+	m.baseContent = d.BaseContent
 }
 
 func atomicWrite(path string, data []byte, perm fs.FileMode) error {
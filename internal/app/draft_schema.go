@@ -0,0 +1,94 @@
+package app
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+/* ------------------- Schema-Version und Migration der Entwürfe ------------ */
+
+// draftSchemaVersion ist die Version, die writeDraft schreibt. Entwürfe ohne
+// schema_version gelten als Version 1.
+const draftSchemaVersion = 3
+
+//go:embed draft.schema.json
+var draftSchemaJSON []byte
+
+// draftMigrations[i] hebt einen Entwurf von Version i+1 auf i+2 an. Neue
+// Formatänderungen hängen hier eine Stufe an und erhöhen draftSchemaVersion.
+var draftMigrations = []func(map[string]any) error{
+	migrateDraftV1,
+	migrateDraftV2,
+}
+
+// migrateDraftV1: Version 1 ist das Format vor der Versionierung. Es hat
+// dieselben Felder wie Version 2 (status_idx, Listen stets als Array), die
+// Stufe ändert also nichts; die Version setzt decodeDraft.
+func migrateDraftV1(map[string]any) error {
+	return nil
+}
+
+// migrateDraftV2: Version 3 führt pruefregeln, verweise, autor und
+// signing_key. Ältere Entwürfe eines bestehenden ADRs übernehmen sie aus
+// base_content, sonst gingen sie beim nächsten Speichern verloren.
+func migrateDraftV2(raw map[string]any) error {
+	base, _ := raw["base_content"].(string)
+	if base == "" {
+		return nil
+	}
+	path, _ := raw["editing_path"].(string)
+	p := parseADRText(path, base)
+	for k, v := range map[string]string{
+		"pruefregeln": p.Pruefregeln, "verweise": p.Verweise, "autor": p.Autor, "signing_key": p.SigningKey,
+	} {
+		if _, ok := raw[k]; !ok && v != "" {
+			raw[k] = v
+		}
+	}
+	return nil
+}
+
+// decodeDraft liest einen Entwurf beliebiger bekannter Version. Neuere
+// Versionen und unbekannte Felder werden abgelehnt statt still verworfen –
+// wie im Schema (additionalProperties: false).
+func decodeDraft(b []byte) (draftFile, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return draftFile{}, err
+	}
+	v := 1
+	if f, ok := raw["schema_version"].(float64); ok {
+		v = int(f)
+	}
+	if v > draftSchemaVersion {
+		return draftFile{}, fmt.Errorf("Entwurf hat Schema-Version %d, unterstützt wird bis %d – bitte ADRonaut aktualisieren", v, draftSchemaVersion)
+	}
+	if v < 1 {
+		return draftFile{}, fmt.Errorf("ungültige Schema-Version %d", v)
+	}
+	for ; v < draftSchemaVersion; v++ {
+		if err := draftMigrations[v-1](raw); err != nil {
+			return draftFile{}, fmt.Errorf("Migration von Version %d: %w", v, err)
+		}
+	}
+	raw["schema_version"] = draftSchemaVersion
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return draftFile{}, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	var d draftFile
+	err = dec.Decode(&d)
+	return d, err
+}
+
+func runSchema(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("schema: keine Argumente erwartet")
+	}
+	fmt.Print(string(draftSchemaJSON))
+	return nil
+}
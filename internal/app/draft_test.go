@@ -0,0 +1,230 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func withRoots(t *testing.T, roots ...adrRoot) {
+	t.Helper()
+	old, oldDir := adrRoots, autosaveDir
+	t.Cleanup(func() { adrRoots, autosaveDir = old, oldDir })
+	adrRoots = roots
+	autosaveDir = ".adronaut"
+}
+
+// Gleichnamige ADRs in zwei Teilbäumen dürfen sich Entwurf und Sperre nicht
+// teilen.
+func TestDraftNameSeparatesRoots(t *testing.T) {
+	withRoots(t,
+		adrRoot{NS: "", Dir: filepath.Join("docs", "adr")},
+		adrRoot{NS: "billing", Dir: filepath.Join("services", "billing", "docs", "adr")},
+		adrRoot{NS: "payments", Dir: filepath.Join("services", "payments", "docs", "adr")},
+	)
+	home := filepath.Join("docs", "adr", "ADR-0001-x.md")
+	if got := draftName(home); got != "ADR-0001-x.md.draft.json" {
+		t.Errorf("draftName(%s) = %s", home, got)
+	}
+	a := fileOption{Path: filepath.Join("services", "billing", "docs", "adr", "ADR-0001-x.md")}
+	b := fileOption{Path: filepath.Join("services", "payments", "docs", "adr", "ADR-0001-x.md")}
+	if lockKey(a) == lockKey(b) || draftName(a.Path) == draftName(b.Path) {
+		t.Errorf("same key for %s and %s: %s", a.Path, b.Path, lockKey(a))
+	}
+	if want := "services--billing--docs--adr--ADR-0001-x.md.draft.json"; lockKey(a) != want {
+		t.Errorf("lockKey = %s, want %s", lockKey(a), want)
+	}
+}
+
+// Alte Entwürfe, die nur nach dem Dateinamen hießen, ziehen samt
+// Rückgängig-Stapel und Historie unter den neuen Namen um.
+func TestRenameDraftsMigratesLegacyNames(t *testing.T) {
+	t.Chdir(t.TempDir())
+	withRoots(t,
+		adrRoot{NS: "", Dir: filepath.Join("docs", "adr")},
+		adrRoot{NS: "billing", Dir: filepath.Join("services", "billing", "docs", "adr")},
+	)
+	adr := filepath.Join("services", "billing", "docs", "adr", "ADR-0001-x.md")
+	homeADR := filepath.Join("docs", "adr", "ADR-0002-y.md")
+	write := func(path string, v any) {
+		t.Helper()
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	legacy := filepath.Join(autosaveDir, "ADR-0001-x.md.draft.json")
+	write(legacy, draftFile{SchemaVersion: draftSchemaVersion, EditingPath: adr, Title: "x"})
+	write(undoPath(legacy), undoFile{})
+	write(filepath.Join(snapshotDir(legacy), "1-abc.json"), draftFile{})
+	home := filepath.Join(autosaveDir, "ADR-0002-y.md.draft.json")
+	write(home, draftFile{SchemaVersion: draftSchemaVersion, EditingPath: homeADR, Title: "y"})
+
+	if err := renameDrafts(); err != nil {
+		t.Fatal(err)
+	}
+	moved := filepath.Join(autosaveDir, draftName(adr))
+	for _, p := range []string{moved, undoPath(moved), filepath.Join(snapshotDir(moved), "1-abc.json"), home} {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("missing %s: %v", p, err)
+		}
+	}
+	for _, p := range []string{legacy, undoPath(legacy), snapshotDir(legacy)} {
+		if _, err := os.Stat(p); err == nil {
+			t.Errorf("%s still exists", p)
+		}
+	}
+}
+
+// Ohne ADRs und Entwürfe startet die TUI mit einem neuen Entwurf; headless
+// darf dafür keine Sperre liegen bleiben.
+func TestHeadlessModelTakesNoLock(t *testing.T) {
+	t.Chdir(t.TempDir())
+	withRoots(t, adrRoot{NS: "", Dir: filepath.Join("docs", "adr")})
+	m := headlessModel()
+	if m.startup || m.draftFixedPath == "" {
+		t.Fatalf("startup = %v, draft = %q: want the new-draft branch", m.startup, m.draftFixedPath)
+	}
+	if entries, _ := os.ReadDir(lockDir()); len(entries) > 0 {
+		t.Errorf("locks left behind: %v", entries)
+	}
+}
+
+func TestRestoreSnapshotIsUndoable(t *testing.T) {
+	t.Chdir(t.TempDir())
+	withRoots(t, adrRoot{NS: "", Dir: filepath.Join("docs", "adr")})
+	m := newBlankModel()
+	m.draftFixedPath = newDraftPath()
+	m.title.SetValue("neu")
+	m.restoreSnapshot(snapshot{Draft: draftFile{Title: "alt"}})
+	if m.Title() != "alt" {
+		t.Fatalf("title = %q after restore", m.Title())
+	}
+	m, _ = m.undo()
+	if m.Title() != "neu" {
+		t.Errorf("title = %q after undo, want neu", m.Title())
+	}
+}
+
+func TestAnalyzeDraftsListsUnreadable(t *testing.T) {
+	t.Chdir(t.TempDir())
+	withRoots(t, adrRoot{NS: "", Dir: filepath.Join("docs", "adr")})
+	if err := os.MkdirAll(autosaveDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"kaputt.draft.json": "{",
+		"neuer.draft.json":  `{"schema_version": 99, "title": "X"}`,
+	}
+	for n, c := range files {
+		if err := os.WriteFile(filepath.Join(autosaveDir, n), []byte(c), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	items := analyzeDrafts()
+	if len(items) != len(files) {
+		t.Fatalf("got %d drafts, want %d", len(items), len(files))
+	}
+	for _, di := range items {
+		if di.State != draftUnreadable || di.Err == nil {
+			t.Errorf("%s: state = %s, err = %v", di.Opt.Path, draftStateNames[di.State], di.Err)
+		}
+	}
+}
+
+// Ein Entwurf aus der Zeit vor schema_version, wie ihn ältere Versionen
+// geschrieben haben.
+const draftV1Fixture = `{
+  "editing_path": "docs/adr/ADR-0007-wahl-des-service-mesh.md",
+  "editing_no": 7,
+  "title": "Wahl des Service Mesh",
+  "status_idx": 1,
+  "kontext": "Wir brauchen mTLS.",
+  "entscheidung": ["Linkerd"],
+  "konsequenzen": [""],
+  "alternativen": ["Istio", "Consul"],
+  "beteiligte": "Alice, Bob",
+  "tags": "netz",
+  "saved_at": "2024-03-02T10:00:00Z",
+  "created_date": "2024-03-01"
+}`
+
+func TestDecodeDraftV1(t *testing.T) {
+	d, err := decodeDraft([]byte(draftV1Fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.SchemaVersion != draftSchemaVersion {
+		t.Errorf("schema_version = %d, want %d", d.SchemaVersion, draftSchemaVersion)
+	}
+	if d.Title != "Wahl des Service Mesh" || d.StatusIdx != 1 || d.EditingNo != 7 ||
+		len(d.Alternativen) != 2 || d.Alternativen[1] != "Consul" || d.CreatedDate != "2024-03-01" {
+		t.Errorf("decoded = %+v", d)
+	}
+
+	if _, err := decodeDraft([]byte(`{"schema_version": 3, "title": "X", "status_idx": 0, "unbekannt": 1}`)); err == nil {
+		t.Error("unknown field: want error")
+	}
+}
+
+// Das Schema beschreibt genau die Felder, die writeDraft schreibt.
+func TestDraftSchemaMatchesDraftFile(t *testing.T) {
+	var schema struct {
+		Properties map[string]struct {
+			Const *int `json:"const"`
+		} `json:"properties"`
+		AdditionalProperties bool `json:"additionalProperties"`
+	}
+	if err := json.Unmarshal(draftSchemaJSON, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if schema.AdditionalProperties {
+		t.Error("additionalProperties must be false")
+	}
+	if c := schema.Properties["schema_version"].Const; c == nil || *c != draftSchemaVersion {
+		t.Errorf("schema_version const = %v, want %d", c, draftSchemaVersion)
+	}
+	typ := reflect.TypeOf(draftFile{})
+	tags := map[string]bool{}
+	for i := range typ.NumField() {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		tags[name] = true
+		if _, ok := schema.Properties[name]; !ok {
+			t.Errorf("field %q missing in draft.schema.json", name)
+		}
+	}
+	for name := range schema.Properties {
+		if !tags[name] {
+			t.Errorf("schema property %q not in draftFile", name)
+		}
+	}
+}
+
+// Entwürfe der Version 2 kennen Verweise, Autor und Signing-Key noch nicht;
+// die Migration holt sie aus der Merge-Basis.
+func TestDecodeDraftV2TakesFieldsFromBase(t *testing.T) {
+	b, err := json.Marshal(map[string]any{
+		"schema_version": 2, "editing_path": "docs/adr/ADR-0007-wahl-des-service-mesh.md",
+		"title": "Wahl des Service Mesh", "status_idx": 0, "base_content": bulkFixture,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := decodeDraft(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.SchemaVersion != draftSchemaVersion || d.Autor != "Alice <a@x>" || d.SigningKey != "ABCDEF" ||
+		d.Verweise != "- [ADR-0003](ADR-0003-netz.md)" {
+		t.Errorf("decoded = %+v", d)
+	}
+}
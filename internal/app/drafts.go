@@ -0,0 +1,405 @@
+package app
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+/* ------------------ Entwürfe aufräumen: verwaist, identisch, alt ---------- */
+
+type draftState int
+
+const (
+	draftNew        draftState = iota // neuer ADR, noch keine Datei
+	draftModified                     // weicht von der Datei ab
+	draftIdentical                    // inhaltlich gleich wie die Datei
+	draftOrphaned                     // Quelldatei existiert nicht mehr
+	draftUnreadable                   // Entwurf kaputt oder aus neuerer Version
+)
+
+var draftStateNames = map[draftState]string{
+	draftNew:        "neu",
+	draftModified:   "geändert",
+	draftIdentical:  "identisch",
+	draftOrphaned:   "verwaist",
+	draftUnreadable: "unlesbar",
+}
+
+type draftInfo struct {
+	Opt     fileOption
+	Draft   draftFile
+	Saved   time.Time
+	State   draftState
+	Changed []string // geänderte Abschnitte
+	Err     error    // Grund bei draftUnreadable
+	file    []string // Abschnitte der Quelldatei (für den Diff)
+}
+
+// analyzeDraft vergleicht einen Entwurf abschnittsweise mit seiner
+// Quelldatei. Beide Seiten laufen durch denselben Lader wie der Editor.
+func analyzeDraft(o fileOption) (draftInfo, error) {
+	df, err := readDraftFile(o.Path)
+	if err != nil {
+		return draftInfo{}, err
+	}
+	di := draftInfo{Opt: o, Draft: df, Saved: df.SavedAt}
+	if di.Saved.IsZero() {
+		if st, err := os.Stat(o.Path); err == nil {
+			di.Saved = st.ModTime()
+		}
+	}
+	if df.EditingPath == "" {
+		return di, nil
+	}
+	if _, err := os.Stat(df.EditingPath); errors.Is(err, os.ErrNotExist) {
+		di.State = draftOrphaned
+		return di, nil
+	}
+	e, err := model{}.loadOption(adrOption(df.EditingPath))
+	if err != nil {
+		return di, err
+	}
+	di.file = draftSections(e.toDraft())
+	mine := draftSections(df)
+	for i, name := range mergeSectionNames {
+		if normSection(mine[i]) != normSection(di.file[i]) {
+			di.Changed = append(di.Changed, name)
+		}
+	}
+	di.State = draftModified
+	if len(di.Changed) == 0 {
+		di.State = draftIdentical
+	}
+	return di, nil
+}
+
+// analyzeDrafts listet alle Entwürfe; was sich nicht auswerten lässt, bleibt
+// als draftUnreadable mit Fehler in der Liste, damit es verworfen werden kann.
+func analyzeDrafts() []draftInfo {
+	var out []draftInfo
+	for _, o := range scanDrafts() {
+		di, err := analyzeDraft(o)
+		if err != nil {
+			di = draftInfo{Opt: o, State: draftUnreadable, Err: err}
+			if st, serr := os.Stat(o.Path); serr == nil {
+				di.Saved = st.ModTime()
+			}
+		}
+		out = append(out, di)
+	}
+	return out
+}
+
+func (di draftInfo) source() string {
+	if di.Draft.EditingPath == "" {
+		return "–"
+	}
+	return di.Draft.EditingPath
+}
+
+// sectionDiff zeigt die geänderten Abschnitte als Zeilen-Diff (− Datei, + Entwurf).
+func (di draftInfo) sectionDiff() string {
+	if di.State != draftModified {
+		return ""
+	}
+	mine := draftSections(di.Draft)
+	var b strings.Builder
+	for i, name := range mergeSectionNames {
+		ld := lineDiff(splitLines(normSection(di.file[i])), splitLines(normSection(mine[i])))
+		if diffChanged(ld) {
+			b.WriteString(labelStyle.Render(name) + "\n" + renderDiff(ld, 2) + "\n\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func humanAge(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case t.IsZero():
+		return "?"
+	case d < time.Hour:
+		return fmt.Sprintf("%d Min.", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%d Std.", int(d.Hours()))
+	}
+	return fmt.Sprintf("%d Tage", int(d.Hours()/24))
+}
+
+// discardDraft löscht einen Entwurf samt Historie und Rückgängig-Stapel.
+func discardDraft(path string) error {
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	removeDraftSidecars(path)
+	return nil
+}
+
+/* ------------------------------ Ansicht (ALT+E) --------------------------- */
+
+type draftsView struct {
+	items   []draftInfo
+	idx     int
+	marked  map[string]bool
+	confirm bool
+}
+
+func (m model) openDrafts() (model, tea.Cmd) {
+	m.drafts = &draftsView{items: analyzeDrafts(), marked: map[string]bool{}}
+	m.notice = ""
+	return m, nil
+}
+
+func (dv *draftsView) markState(st draftState) {
+	for _, di := range dv.items {
+		if di.State == st {
+			dv.marked[di.Opt.Path] = true
+		}
+	}
+}
+
+func (dv *draftsView) targets() []draftInfo {
+	var out []draftInfo
+	for _, di := range dv.items {
+		if dv.marked[di.Opt.Path] {
+			out = append(out, di)
+		}
+	}
+	if len(out) == 0 && dv.idx < len(dv.items) {
+		out = append(out, dv.items[dv.idx])
+	}
+	return out
+}
+
+func (m model) updateDrafts(msg tea.KeyMsg) (model, tea.Cmd) {
+	dv := m.drafts
+	if dv.confirm {
+		switch strings.ToLower(msg.String()) {
+		case "j", "y", "enter":
+			dv.confirm = false
+			return m.discardDrafts(dv.targets()), nil
+		case "n", "esc":
+			dv.confirm = false
+		}
+		return m, nil
+	}
+	switch msg.String() {
+	case "up", "ctrl+p", "k":
+		if dv.idx > 0 {
+			dv.idx--
+		}
+	case "down", "ctrl+n", "j":
+		if dv.idx < len(dv.items)-1 {
+			dv.idx++
+		}
+	case " ", "space":
+		if dv.idx < len(dv.items) {
+			p := dv.items[dv.idx].Opt.Path
+			if dv.marked[p] {
+				delete(dv.marked, p)
+			} else {
+				dv.marked[p] = true
+			}
+		}
+	case "i":
+		dv.markState(draftIdentical)
+	case "o":
+		dv.markState(draftOrphaned)
+	case "u":
+		dv.markState(draftUnreadable)
+	case "x", "delete":
+		if len(dv.targets()) > 0 {
+			dv.confirm = true
+		}
+	case "enter":
+		if dv.idx < len(dv.items) && dv.items[dv.idx].State != draftUnreadable {
+			o := dv.items[dv.idx].Opt
+			m.drafts = nil
+			if li, held := m.lockedByOther(o); held {
+				m.offerTakeover(o, li)
+				if !m.selectPath(o.Path) {
+					m.filter.SetValue("")
+					m.applyFilter("")
+					m.selectPath(o.Path)
+				}
+				return m, nil
+			}
+			return m.openOption(o)
+		}
+	case "esc", "alt+e":
+		m.drafts = nil
+	}
+	return m, nil
+}
+
+// discardDrafts verwirft die Entwürfe; gesperrte werden übersprungen.
+func (m model) discardDrafts(targets []draftInfo) model {
+	done, skipped := 0, 0
+	for _, di := range targets {
+		if _, held := m.lockedByOther(di.Opt); held {
+			skipped++
+			continue
+		}
+		if err := discardDraft(di.Opt.Path); err != nil {
+			m.err = err
+			continue
+		}
+		m.replaceOption(di.Opt.Path, "")
+		done++
+	}
+	m.drafts = &draftsView{items: analyzeDrafts(), marked: map[string]bool{}}
+	m.drafts.idx = min(m.drafts.idx, max(0, len(m.drafts.items)-1))
+	m.notice = fmt.Sprintf("%d Entwurf/Entwürfe verworfen", done)
+	if skipped > 0 {
+		m.notice += fmt.Sprintf(", %d gesperrt übersprungen", skipped)
+	}
+	return m
+}
+
+func (m model) viewDrafts() string {
+	dv := m.drafts
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("ADRonaut – Entwürfe aufräumen") + "\n\n")
+	if m.notice != "" {
+		b.WriteString(okStyle.Render(m.notice) + "\n\n")
+	}
+	if len(dv.items) == 0 {
+		b.WriteString(helpStyle.Render("Keine Entwürfe vorhanden.") + "\n\n" + m.help("ESC zurück"))
+		return b.String()
+	}
+
+	var list strings.Builder
+	list.WriteString("  " + labelStyle.Render(fmt.Sprintf("%-30s %-9s %-10s %s", "Entwurf", "Alter", "Zustand", "Quelle")) + "\n")
+	for i, di := range dv.items {
+		st := optionStyle
+		if i == dv.idx {
+			st = selectedStyle
+		}
+		mark := "  "
+		if dv.marked[di.Opt.Path] {
+			mark = "● "
+		}
+		state := draftStateNames[di.State]
+		line := fmt.Sprintf("%s %s %s %s", fitCell(strings.TrimPrefix(di.Opt.Label, "🔄 Entwurf: "), 30),
+			fitCell(humanAge(di.Saved), 9), fitCell(state, 10), filepath.Base(di.source()))
+		list.WriteString(mark + st.Render(line) + "\n")
+	}
+
+	cur := dv.items[dv.idx]
+	var detail strings.Builder
+	switch cur.State {
+	case draftNew:
+		detail.WriteString(helpStyle.Render("Entwurf für einen neuen ADR – keine Datei zum Vergleichen."))
+	case draftOrphaned:
+		detail.WriteString(errorStyle.Render(cur.Draft.EditingPath + " existiert nicht mehr."))
+	case draftIdentical:
+		detail.WriteString(helpStyle.Render("Inhaltlich identisch mit " + cur.Draft.EditingPath + "."))
+	case draftUnreadable:
+		detail.WriteString(errorStyle.Render(cur.Err.Error()))
+	default:
+		detail.WriteString(labelStyle.Render("Abweichungen zu "+cur.Draft.EditingPath) + "  " + helpStyle.Render("(− Datei, + Entwurf)") + "\n\n")
+		detail.WriteString(cur.sectionDiff())
+	}
+
+	listW := 72
+	if m.width > 0 && m.width-2*framePadding < 110 {
+		b.WriteString(list.String() + "\n" + detail.String())
+	} else {
+		dw := max(30, m.width-2*framePadding-listW-2)
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(listW).Render(list.String()), "  ",
+			lipgloss.NewStyle().Width(dw).Render(detail.String())))
+	}
+	b.WriteString("\n\n")
+	if dv.confirm {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("%d Entwurf/Entwürfe endgültig verwerfen?", len(dv.targets()))) +
+			"\n" + m.help("J/ENTER verwerfen · N/ESC abbrechen"))
+		return b.String()
+	}
+	b.WriteString(m.help("↑/↓ wählen · SPACE markieren · I identische / O verwaiste / U unlesbare markieren · X verwerfen · ENTER öffnen · ESC zurück"))
+	return b.String()
+}
+
+/* ---------------------------- adronaut drafts ----------------------------- */
+
+func runDrafts(args []string) error {
+	fs := flag.NewFlagSet("drafts", flag.ContinueOnError)
+	diff := fs.Bool("diff", false, "Abweichungen je Abschnitt anzeigen")
+	discard := fs.String("discard", "", "Entwürfe verwerfen: identical, orphaned, unreadable (Komma-getrennt)")
+	yes := fs.Bool("yes", false, "ohne Rückfrage verwerfen")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Benutzung: adronaut drafts [--diff] [--discard identical,orphaned,unreadable [--yes]]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	want := map[draftState]bool{}
+	for _, s := range splitCSV(*discard) {
+		switch strings.ToLower(s) {
+		case "identical", "identisch":
+			want[draftIdentical] = true
+		case "orphaned", "verwaist":
+			want[draftOrphaned] = true
+		case "unreadable", "unlesbar":
+			want[draftUnreadable] = true
+		default:
+			return fmt.Errorf("--discard: unbekannter Zustand %q", s)
+		}
+	}
+
+	items := analyzeDrafts()
+	if len(items) == 0 {
+		fmt.Println("Keine Entwürfe vorhanden.")
+		return nil
+	}
+	m := headlessModel()
+	fmt.Println(helpStyle.Render("Entwürfe in " + autosaveDir))
+	failed := 0
+	for _, di := range items {
+		changed := ""
+		if len(di.Changed) > 0 {
+			changed = " (" + strings.Join(di.Changed, ", ") + ")"
+		}
+		fmt.Printf("%-48s %-9s %-10s %s%s\n", filepath.Base(di.Opt.Path), humanAge(di.Saved), draftStateNames[di.State], di.source(), changed)
+		if *diff && di.State == draftModified {
+			fmt.Println(indent(di.sectionDiff(), "    "))
+		}
+		if di.State == draftUnreadable {
+			fmt.Println(errorStyle.Render("    " + di.Err.Error()))
+		}
+		if !want[di.State] {
+			continue
+		}
+		if li, held := m.lockedByOther(di.Opt); held {
+			fmt.Println(helpStyle.Render("    übersprungen: wird bearbeitet von " + li.owner()))
+			continue
+		}
+		if !*yes {
+			fmt.Println(helpStyle.Render("    würde verworfen (mit --yes ausführen)"))
+			continue
+		}
+		if err := discardDraft(di.Opt.Path); err != nil {
+			failed++
+			fmt.Fprintln(os.Stderr, errorStyle.Render("✘ ")+err.Error())
+			continue
+		}
+		fmt.Println(okStyle.Render("    ✔ verworfen"))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d Entwurf/Entwürfe konnten nicht verworfen werden", failed)
+	}
+	return nil
+}
+
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
@@ -0,0 +1,218 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ---------------- Feld oder ganzen ADR in $VISUAL/$EDITOR ----------------- */
+
+const editorHelp = " · ALT+E Feld/ALT+SHIFT+E ADR im Editor"
+
+// externalEditDoneMsg kommt, wenn der Editor beendet ist. step < 0 heißt:
+// der ganze ADR wurde bearbeitet.
+type externalEditDoneMsg struct {
+	path string
+	step int
+	item int
+	err  error
+}
+
+// editorCommand liefert $VISUAL, sonst $EDITOR (auch mit Argumenten wie
+// "code --wait"), sonst vi bzw. notepad.
+func editorCommand(path string) *exec.Cmd {
+	ed := strings.Fields(os.Getenv("VISUAL"))
+	if len(ed) == 0 {
+		ed = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(ed) == 0 {
+		ed = []string{"vi"}
+		if runtime.GOOS == "windows" {
+			ed = []string{"notepad"}
+		}
+	}
+	return exec.Command(ed[0], append(ed[1:], path)...)
+}
+
+// openExternalEditor hält die Oberfläche an und öffnet das aktuelle Feld –
+// beim Status und beim Speichern bzw. mit whole den ganzen ADR – im Editor.
+func (m *model) openExternalEditor(whole bool) tea.Cmd {
+	step, item := m.step, 0
+	if whole || step == 1 || step == 8 {
+		step = -1
+	}
+	text := m.extDraft
+	if step >= 0 {
+		text, item = m.fieldText(step)
+	} else if text == "" {
+		text = buildMarkdownPreview(*m)
+	}
+	f, err := os.CreateTemp("", "adronaut-*.md")
+	if err == nil {
+		_, err = f.WriteString(text)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		m.extErr = fmt.Errorf("Editor: %w", err)
+		return nil
+	}
+	path := f.Name()
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		return externalEditDoneMsg{path: path, step: step, item: item, err: err}
+	})
+}
+
+func (m model) fieldText(step int) (string, int) {
+	switch step {
+	case 0:
+		return m.title.Value() + "\n", 0
+	case 2:
+		return m.kontext.Value() + "\n", 0
+	case 3, 4, 5:
+		lf := m.lists()[step-3]
+		return lf.current().Value() + "\n", lf.idx
+	case 6:
+		return strings.Join(splitCSV(m.beteiligte.Value()), "\n") + "\n", 0
+	case 7:
+		return strings.Join(splitCSV(m.tags.Value()), "\n") + "\n", 0
+	}
+	return "", 0
+}
+
+func (m *model) lists() []*listField {
+	return []*listField{&m.entscheidung, &m.konsequenzen, &m.alternativen}
+}
+
+// applyExternalEdit übernimmt den Text aus dem Editor. Ein ganzer ADR wird
+// nur übernommen, wenn er sich vollständig einlesen lässt; sonst bleibt der
+// Text für den nächsten Versuch erhalten.
+func (m model) applyExternalEdit(msg externalEditDoneMsg) (model, tea.Cmd) {
+	b, err := os.ReadFile(msg.path)
+	_ = os.Remove(msg.path)
+	m.extErr = nil
+	if msg.err != nil {
+		m.extErr = fmt.Errorf("Editor: %w", msg.err)
+		return m, nil
+	}
+	if err != nil {
+		m.extErr = fmt.Errorf("Editor: %w", err)
+		return m, nil
+	}
+	text := strings.ReplaceAll(string(b), "\r\n", "\n")
+	before := m.editState()
+
+	switch msg.step {
+	case -1:
+		if err := validateADRText(text); err != nil {
+			m.extDraft = text
+			m.extErr = err
+			return m, nil
+		}
+		m.extDraft = ""
+		m.applyADRText(text)
+	case 0:
+		m.title.SetValue(strings.Join(strings.Fields(text), " "))
+	case 2:
+		m.kontext.SetValue(normSection(text))
+	case 3, 4, 5:
+		lf := m.lists()[msg.step-3]
+		if msg.item < len(lf.items) {
+			lf.items[msg.item].SetValue(normSection(text))
+		}
+	case 6:
+		m.beteiligte.SetValue(trimJoin(splitLinesCSV(text)))
+	case 7:
+		m.tags.SetValue(trimJoin(splitLinesCSV(text)))
+	}
+	if m.undoable() {
+		m.trackUndo(before, tea.KeyMsg{})
+	}
+	return m, m.focusForStep()
+}
+
+// splitLinesCSV: ein Eintrag je Zeile oder kommagetrennt.
+func splitLinesCSV(s string) []string {
+	return splitCSV(strings.ReplaceAll(s, "\n", ","))
+}
+
+// applyADRText liest den ganzen ADR wie beim Laden einer Datei ein. Nummer
+// und Bearbeitungsvermerk bleiben, die setzt erst das Speichern.
+func (m *model) applyADRText(text string) {
+	no, by, at := m.editingNo, m.lastEditedBy, m.lastEditedAt
+	p := parseADRText("", text)
+	m.kontext.SetValue("")
+	m.beteiligte.SetValue("")
+	m.tags.SetValue("")
+	m.fillFromParsed(p)
+	m.kontext.SetValue(normSection(p.Kontext))
+	for _, lf := range m.lists() {
+		for k := range lf.items {
+			if normSection(lf.items[k].Value()) == "" {
+				lf.items[k].SetValue("")
+			}
+		}
+	}
+	m.editingNo, m.lastEditedBy, m.lastEditedAt = no, by, at
+}
+
+var adrHeadingRe = regexp.MustCompile(`(?m)^##\s+(.*?)\s*$`)
+
+// validateADRText prüft, was beim Einlesen sonst stillschweigend verloren
+// ginge: Titel, Status und die Abschnitte.
+func validateADRText(text string) error {
+	var probs []string
+	p := parseADRText("", text)
+	if strings.TrimSpace(p.Title) == "" {
+		probs = append(probs, "Überschrift „# Titel“ fehlt")
+	}
+	switch {
+	case p.Status == "":
+		probs = append(probs, "Zeile „| Status | … |“ fehlt")
+	case !containsFold(statuses, p.Status):
+		probs = append(probs, fmt.Sprintf("unbekannter Status „%s“ (%s)", p.Status, strings.Join(statuses, ", ")))
+	}
+	for _, h := range []string{"Kontext", "Entscheidung", "Alternativen", "Konsequenzen"} {
+		if !regexp.MustCompile(`(?m)^##\s+` + h + `\s*$`).MatchString(text) {
+			probs = append(probs, "Abschnitt „## "+h+"“ fehlt")
+		}
+	}
+	for _, h := range unknownSections(text) {
+		probs = append(probs, "Abschnitt „## "+h+"“ wird nicht übernommen")
+	}
+	if len(probs) > 0 {
+		return errors.New(strings.Join(probs, "; "))
+	}
+	return nil
+}
+
+// unknownSections liefert die Abschnitte, die buildMarkdown nicht kennt und
+// beim Speichern daher verwerfen würde.
+func unknownSections(text string) []string {
+	known := map[string]bool{"Kontext": true, "Entscheidung": true, "Alternativen": true,
+		"Konsequenzen": true, rulesHeading: true, "Verweise": true}
+	var out []string
+	for _, mm := range adrHeadingRe.FindAllStringSubmatch(text, -1) {
+		if !known[mm[1]] {
+			out = append(out, mm[1])
+		}
+	}
+	return out
+}
+
+func containsFold(list []string, s string) bool {
+	for _, x := range list {
+		if strings.EqualFold(x, strings.TrimSpace(s)) {
+			return true
+		}
+	}
+	return false
+}
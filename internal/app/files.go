@@ -1,10 +1,7 @@
 package app
 
 import (
-	"encoding/json"
-	"errors"
 	"fmt"
-	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -13,9 +10,14 @@ import (
 	"time"
 )
 
-var adrFileRe = regexp.MustCompile(`^ADR-([0-9]{4})-.*\.md$`)
+var adrFileRe = regexp.MustCompile(`^ADR-([0-9]{4,})-.*\.md$`)
 
 func scanADRFiles(dir string) []fileOption {
+	r, _ := rootFor(filepath.Join(dir, "x"))
+	return scanADRFilesNS(dir, r.NS)
+}
+
+func scanADRFilesNS(dir, ns string) []fileOption {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil
@@ -29,20 +31,7 @@ func scanADRFiles(dir string) []fileOption {
 		if !adrFileRe.MatchString(name) {
 			continue
 		}
-		no := 0
-		if m := adrFileRe.FindStringSubmatch(name); len(m) == 2 {
-			fmt.Sscanf(m[1], "%04d", &no)
-		}
-		path := filepath.Join(dir, name)
-		lbl := quickTitleForFile(path)
-		if lbl == "" {
-			lbl = name
-		}
-		opts = append(opts, fileOption{
-			Label: fmt.Sprintf("%04d — %s", no, lbl),
-			Path:  path,
-			No:    no,
-		})
+		opts = append(opts, adrOptionNS(filepath.Join(dir, name), ns))
 	}
 	sort.Slice(opts, func(i, j int) bool {
 		if opts[i].No == 0 && opts[j].No == 0 {
@@ -59,8 +48,39 @@ func scanADRFiles(dir string) []fileOption {
 	return opts
 }
 
-func scanDrafts(dir string) []fileOption {
-	asDir := filepath.Join(dir, autosaveDir)
+// adrOption baut den Picker-Eintrag für eine ADR-Datei.
+func adrOption(path string) fileOption {
+	r, _ := rootFor(path)
+	return adrOptionNS(path, r.NS)
+}
+
+func adrOptionNS(path, ns string) fileOption {
+	name := filepath.Base(path)
+	no, _ := adrNumber(name)
+	lbl := quickTitleForFile(path)
+	if lbl == "" {
+		lbl = name
+	}
+	o := fileOption{Path: path, No: no, NS: ns}
+	o.Label = o.number() + " — " + lbl
+	if no == 0 {
+		o.Label = fmt.Sprintf("%04d — %s", no, lbl)
+	}
+	return o
+}
+
+// draftOption baut den Picker-Eintrag für eine Entwurfsdatei.
+func draftOption(path string) fileOption {
+	title, base := draftTitlePreview(path)
+	lbl := "🔄 Entwurf: " + title
+	if title == "" {
+		lbl = "🔄 Entwurf: " + base
+	}
+	return fileOption{Label: lbl, Path: path, No: 0, Draft: true}
+}
+
+func scanDrafts() []fileOption {
+	asDir := autosaveDir
 	dh, err := os.ReadDir(asDir)
 	if err != nil {
 		return nil
@@ -79,18 +99,13 @@ func scanDrafts(dir string) []fileOption {
 			continue
 		}
 		full := filepath.Join(asDir, name)
-		title, base := draftTitlePreview(full)
-		lbl := "🔄 Entwurf: " + title
-		if title == "" {
-			lbl = "🔄 Entwurf: " + base
-		}
 		st, _ := os.Stat(full)
 		mt := time.Time{}
 		if st != nil {
 			mt = st.ModTime()
 		}
 		items = append(items, item{
-			opt: fileOption{Label: lbl, Path: full, No: 0, Draft: true},
+			opt: draftOption(full),
 			mod: mt,
 		})
 	}
@@ -124,34 +139,6 @@ func quickTitleForFile(path string) string {
 	return t
 }
 
-func nextADRNumber(dir string) (int, error) {
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		if errors.Is(err, fs.ErrNotExist) {
-			return 1, nil
-		}
-		return 0, err
-	}
-	re := regexp.MustCompile(`^ADR-([0-9]{4})-`)
-	nums := []int{}
-	for _, e := range entries {
-		if e.IsDir() {
-			continue
-		}
-		m := re.FindStringSubmatch(e.Name())
-		if len(m) == 2 {
-			var n int
-			fmt.Sscanf(m[1], "%04d", &n)
-			nums = append(nums, n)
-		}
-	}
-	if len(nums) == 0 {
-		return 1, nil
-	}
-	sort.Ints(nums)
-	return nums[len(nums)-1] + 1, nil
-}
-
 func ensureDir(dir string) error { return os.MkdirAll(dir, 0o755) }
 
 func slugify(s string) string {
@@ -176,12 +163,7 @@ func noTitleSlug() string {
 
 func draftTitlePreview(path string) (title, base string) {
 	base = filepath.Base(path)
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return "", base
-	}
-	var d draftFile
-	if json.Unmarshal(b, &d) == nil {
+	if d, err := readDraftFile(path); err == nil {
 		return strings.TrimSpace(d.Title), base
 	}
 	return "", base
@@ -0,0 +1,238 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+/* ------------- Formular: alle Felder auf einer Seite statt Schritten ------- */
+
+const formSidebarWidth = 20
+
+var formSidebarStyle = lipgloss.NewStyle().
+	Width(formSidebarWidth).
+	MarginRight(2).
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderRight(true).
+	BorderForeground(lipgloss.Color(gbGray))
+
+// formRef ordnet eine Zeile des Formulars einem Feld (Schritt) und bei
+// Listen dem Punkt zu.
+type formRef struct{ step, item int }
+
+// toggleForm wechselt zwischen Assistent und Formular und merkt sich die
+// Wahl für den nächsten Start. Maus-Klicks braucht nur das Formular.
+func (m *model) toggleForm() tea.Cmd {
+	m.form = !m.form
+	m.resizeFields()
+	p := loadPrefs()
+	p.Editor = ""
+	if m.form {
+		p.Editor = editorForm
+	}
+	_ = savePrefs(p)
+	if m.form {
+		return tea.Batch(tea.EnableMouseCellMotion, m.focusForStep())
+	}
+	return tea.Batch(tea.DisableMouse, m.focusForStep())
+}
+
+// jumpTo springt mit ALT+1 … ALT+9 direkt zu einem Feld.
+func (m *model) jumpTo(k string) (tea.Cmd, bool) {
+	n, ok := strings.CutPrefix(k, "alt+")
+	if !ok || len(n) != 1 || n[0] < '1' || n[0] > '9' {
+		return nil, false
+	}
+	m.step = int(n[0] - '1')
+	return m.focusForStep(), true
+}
+
+// formBody rendert alle Felder untereinander.
+func (m model) formBody() (lines []string, owner []formRef) {
+	add := func(s string, ref formRef) {
+		for _, l := range strings.Split(s, "\n") {
+			lines = append(lines, l)
+			owner = append(owner, ref)
+		}
+	}
+	label := func(step int) {
+		st := labelStyle
+		if step == m.step {
+			st = activeStyle
+		}
+		add(st.Render(stepNames[step]), formRef{step, 0})
+	}
+
+	label(0)
+	add(m.title.View()+"\n", formRef{0, 0})
+
+	label(1)
+	var st []string
+	for i, s := range statuses {
+		o := optionStyle
+		if i == m.statusIdx {
+			o = selectedStyle
+		}
+		st = append(st, o.Render(s))
+	}
+	add(strings.Join(st, "   ")+"\n", formRef{1, 0})
+
+	label(2)
+	add(m.kontext.View()+"\n", formRef{2, 0})
+
+	w := m.formFieldWidth()
+	for i, lf := range []listField{m.entscheidung, m.konsequenzen, m.alternativen} {
+		step := 3 + i
+		label(step)
+		for k := range lf.items {
+			if step == m.step && k == lf.idx {
+				add(fmt.Sprintf("%d.\n%s", k+1, lf.items[k].View()), formRef{step, k})
+				continue
+			}
+			v := strings.TrimSpace(lf.items[k].Value())
+			if v == "" {
+				v = helpStyle.Render("(leer)")
+			}
+			add(lipgloss.NewStyle().Width(w).Render(fmt.Sprintf("%d. %s", k+1, v)), formRef{step, k})
+		}
+		add("", formRef{step, len(lf.items) - 1})
+	}
+
+	label(6)
+	add(m.beteiligte.View()+"\n", formRef{6, 0})
+	label(7)
+	add(m.tags.View(), formRef{7, 0})
+	return lines, owner
+}
+
+// formHeight ist die Höhe des sichtbaren Formularbereichs, 0 = unbegrenzt.
+func (m model) formHeight() int {
+	if m.height <= 0 {
+		return 0
+	}
+	return max(5, m.height-lipgloss.Height(m.header())-1) // Leerzeile + Hilfe
+}
+
+// formOffset scrollt so, dass das aktive Feld (bei Listen der aktive Punkt)
+// sichtbar ist.
+func (m model) formOffset(owner []formRef) int {
+	h := m.formHeight()
+	if h == 0 || len(owner) <= h {
+		return 0
+	}
+	item := 0
+	switch m.step {
+	case 3:
+		item = m.entscheidung.idx
+	case 4:
+		item = m.konsequenzen.idx
+	case 5:
+		item = m.alternativen.idx
+	}
+	start, end := -1, 0
+	for i, o := range owner {
+		if o.step != m.step {
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		if o.item == item {
+			end = i + 1
+		}
+	}
+	if start < 0 || end <= h {
+		return 0
+	}
+	off := start
+	if end-off > h {
+		off = end - h
+	}
+	return min(off, len(owner)-h)
+}
+
+func (m model) formFieldWidth() int {
+	return max(40, m.width-2*framePadding-formSidebarWidth-3) // Rand + Abstand
+}
+
+func (m model) viewForm() string {
+	var nav strings.Builder
+	nav.WriteString(labelStyle.Render("Inhalt") + "\n")
+	for i, s := range stepNames {
+		line := fmt.Sprintf("%d %s", i+1, s)
+		if i == m.step {
+			nav.WriteString(activeStyle.Render("▸ "+line) + "\n")
+		} else {
+			nav.WriteString("  " + line + "\n")
+		}
+	}
+	nav.WriteString("\n" + helpStyle.Render("ALT+Ziffer springt"))
+
+	lines, owner := m.formBody()
+	if h := m.formHeight(); h > 0 {
+		off := m.formOffset(owner)
+		lines = lines[off:min(len(lines), off+h)]
+	}
+	side := formSidebarStyle.Height(max(len(lines), lipgloss.Height(nav.String()))).Render(nav.String())
+	body := lipgloss.JoinHorizontal(lipgloss.Top, side, strings.Join(lines, "\n"))
+
+	keys := "TAB/SHIFT+TAB Feld · Klick oder ALT+1…9 springt · F3 Schritt für Schritt"
+	return body + "\n\n" + m.help(keys+undoHelp+editorHelp)
+}
+
+// formClick fokussiert das angeklickte Feld (Seitenleiste oder Formular).
+func (m model) formClick(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	y := msg.Y - (lipgloss.Height(m.header()) - 1) // header endet mit "\n"
+	x := msg.X - framePadding
+	m.extErr = nil
+	if y < 0 || x < 0 {
+		return m, nil
+	}
+	if x < formSidebarWidth {
+		if y >= 1 && y <= len(stepNames) {
+			m.step = y - 1
+			return m, m.focusForStep()
+		}
+		return m, nil
+	}
+	if m.step == 8 {
+		return m, nil
+	}
+	_, owner := m.formBody()
+	if h := m.formHeight(); h > 0 && y >= h {
+		return m, nil
+	}
+	if i := y + m.formOffset(owner); i < len(owner) {
+		ref := owner[i]
+		m.step = ref.step
+		switch ref.step {
+		case 3:
+			m.entscheidung.idx = ref.item
+		case 4:
+			m.konsequenzen.idx = ref.item
+		case 5:
+			m.alternativen.idx = ref.item
+		}
+		return m, m.focusForStep()
+	}
+	return m, nil
+}
+
+// updateMouse: Klicks im Formular, Mausrad in der Vorschau beim Speichern.
+func (m model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.startup || m.merge != nil || m.snaps != nil {
+		return m, nil
+	}
+	switch {
+	case m.step == 8 && msg.Button == tea.MouseButtonWheelUp:
+		m.previewKey("up")
+	case m.step == 8 && msg.Button == tea.MouseButtonWheelDown:
+		m.previewKey("down")
+	case m.form && msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+		return m.formClick(msg)
+	}
+	return m, nil
+}
@@ -0,0 +1,157 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ------------------- Historische ADRs aus der Git-Historie ---------------- */
+
+const historicBadge = "historisch"
+
+type historyLoadedMsg struct {
+	opts []fileOption
+	docs map[string]searchDoc
+	err  error
+}
+
+func loadHistoryCmd() tea.Cmd {
+	return func() tea.Msg {
+		opts, docs, err := scanHistory()
+		return historyLoadedMsg{opts: opts, docs: docs, err: err}
+	}
+}
+
+// scanHistory sucht in "git log" nach gelöschten und umbenannten ADRs und
+// liefert für jeden Pfad die letzte Fassung vor dem Löschen/Umbenennen.
+// Der Pfad einer Option hat die Form "<rev>:<pfad>" und kann direkt an
+// "git show" übergeben werden.
+func scanHistory() ([]fileOption, map[string]searchDoc, error) {
+	out, err := exec.Command("git", "log", "--all", "--diff-filter=DR", "-M",
+		"--name-status", "--format=commit %H").Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("git log: %w", err)
+	}
+
+	root := gitToplevel()
+	seen := map[string]bool{}
+	opts := []fileOption{}
+	docs := map[string]searchDoc{}
+
+	commit := ""
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.HasPrefix(line, "commit ") {
+			commit = strings.TrimPrefix(line, "commit ")
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 || commit == "" {
+			continue
+		}
+		// D<TAB>pfad bzw. R100<TAB>alt<TAB>neu – in beiden Fällen ist fields[1]
+		// der Pfad, der danach nicht mehr existiert.
+		p := fields[1]
+		if seen[p] || !adrFileRe.MatchString(path.Base(p)) {
+			continue
+		}
+		seen[p] = true
+		if root != "" {
+			if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(p))); err == nil {
+				continue // liegt (wieder) im Arbeitsverzeichnis
+			}
+		}
+
+		rev := commit + "^"
+		spec := rev + ":" + p
+		txt, err := gitShow(spec)
+		if err != nil {
+			continue
+		}
+		d := searchDocFromText(txt)
+		no, _ := adrNumber(path.Base(p))
+		lbl := d.Title
+		if lbl == "" {
+			lbl = path.Base(p)
+		}
+		r, _ := rootFor(filepath.FromSlash(p))
+		o := fileOption{Path: spec, No: no, Rev: rev, NS: r.NS}
+		o.Label = fmt.Sprintf("%04d — %s", no, lbl)
+		if r.NS != "" {
+			o.Label = o.number() + " — " + lbl
+		}
+		opts = append(opts, o)
+		docs[spec] = withFullText(o, d)
+	}
+	return opts, docs, nil
+}
+
+func gitShow(spec string) (string, error) {
+	out, err := exec.Command("git", "show", spec).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func gitToplevel() string {
+	out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func parseHistoricForSearch(spec string) searchDoc {
+	txt, err := gitShow(spec)
+	if err != nil {
+		return searchDoc{}
+	}
+	return searchDocFromText(txt)
+}
+
+// loadFromRevision lädt einen historischen ADR in den Editor (nur lesen).
+func (m *model) loadFromRevision(o fileOption) error {
+	txt, err := gitShow(o.Path)
+	if err != nil {
+		return err
+	}
+	m.fillFromParsed(parseADRText(o.Path, txt))
+	m.readOnly = true
+	m.viewRev = o.Rev
+	return nil
+}
+
+// setHistory blendet historische Einträge ein bzw. aus.
+func (m *model) setHistory(opts []fileOption, docs map[string]searchDoc) {
+	all := make([]fileOption, 0, len(m.allOptions)+len(opts))
+	for _, o := range m.allOptions {
+		if o.Rev == "" {
+			all = append(all, o)
+		}
+	}
+	all = append(all, opts...)
+	m.allOptions = all
+	for k, d := range docs {
+		m.searchDocs[k] = d
+	}
+	m.applyFilter(m.filter.Value())
+	m.scrollPick()
+}
+
+func shortRev(rev string) string {
+	rev = strings.TrimSuffix(rev, "^")
+	if len(rev) > 8 {
+		rev = rev[:8]
+	}
+	return rev + "^"
+}
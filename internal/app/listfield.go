@@ -57,6 +57,16 @@ func (lf *listField) deleteCurrent() {
 	}
 }
 
+// moveCurrent verschiebt den aktiven Punkt um delta Positionen.
+func (lf *listField) moveCurrent(delta int) {
+	j := lf.idx + delta
+	if j < 0 || j >= len(lf.items) {
+		return
+	}
+	lf.items[lf.idx], lf.items[j] = lf.items[j], lf.items[lf.idx]
+	lf.idx = j
+}
+
 func (lf *listField) setWidthAll(w int) {
 	for i := range lf.items {
 		lf.items[i].SetWidth(w)
@@ -84,6 +94,12 @@ func (lf *listField) UpdateActive(msg tea.Msg) (tea.Cmd, bool) {
 		case "ctrl+x":
 			lf.deleteCurrent()
 			return lf.focusCurrent(), true
+		case "alt+up":
+			lf.moveCurrent(-1)
+			return lf.focusCurrent(), true
+		case "alt+down":
+			lf.moveCurrent(1)
+			return lf.focusCurrent(), true
 		}
 	}
 	var cmd tea.Cmd
@@ -98,7 +114,14 @@ func (lf *listField) Markdown() string {
 		if txt == "" || strings.EqualFold(txt, "(noch offen)") {
 			continue
 		}
-		parts = append(parts, fmt.Sprintf("%d. %s", i+1, txt))
+		// Folgezeilen eingerückt, damit sie in Markdown zum Punkt gehören.
+		lines := strings.Split(txt, "\n")
+		for j := 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) != "" {
+				lines[j] = "   " + lines[j]
+			}
+		}
+		parts = append(parts, fmt.Sprintf("%d. %s", i+1, strings.Join(lines, "\n")))
 	}
 	if len(parts) == 0 {
 		return "(noch offen)"
@@ -126,6 +149,16 @@ func (lf *listField) Values() []string {
 	}
 	return out
 }
+
+// rawValues liefert alle Punkte unverändert, auch leere.
+func (lf *listField) rawValues() []string {
+	out := make([]string, len(lf.items))
+	for i := range lf.items {
+		out[i] = lf.items[i].Value()
+	}
+	return out
+}
+
 func (lf *listField) SetFromSlice(items []string, h, w int) {
 	if len(items) == 0 {
 		lf.items = []textarea.Model{newTA(lf.placeholder, h, w)}
@@ -143,16 +176,23 @@ func (lf *listField) SetFromSlice(items []string, h, w int) {
 
 func (lf *listField) SetFromMarkdown(text string, h, w int) {
 	text = strings.TrimSpace(text)
-	if text == "" || strings.EqualFold(text, "(noch offen)") {
+	if text == "" || strings.EqualFold(text, "(noch offen)") || strings.EqualFold(text, "(keine oder noch offen)") {
 		lf.items = []textarea.Model{newTA(lf.placeholder, h, w)}
 		lf.idx = 0
 		return
 	}
-	re := regexp.MustCompile(`(?m)^\s*\d+\.\s+(.*\S)\s*$`)
-	matches := re.FindAllStringSubmatch(text, -1)
+	// Zeilen nach einem Punkt gehören dazu (mehrere Absätze je Punkt).
+	re := regexp.MustCompile(`^\s*\d+\.\s+(.*\S)\s*$`)
 	var items []string
-	for _, m := range matches {
-		items = append(items, strings.TrimSpace(m[1]))
+	for _, l := range strings.Split(text, "\n") {
+		if m := re.FindStringSubmatch(l); m != nil {
+			items = append(items, m[1])
+		} else if len(items) > 0 {
+			items[len(items)-1] += "\n" + strings.TrimPrefix(strings.TrimRight(l, " \t\r"), "   ")
+		}
+	}
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
 	}
 	if len(items) == 0 {
 		items = []string{text}
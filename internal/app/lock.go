@@ -0,0 +1,213 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+/* ---------------- Beratende Sperren gegen parallele Sitzungen -------------- */
+
+// lockStaleAfter: ohne Heartbeat gilt eine Sperre danach als verwaist. Der
+// Heartbeat läuft mit jedem Autosave (alle paar Sekunden).
+const lockStaleAfter = 5 * time.Minute
+
+var errLockLost = errors.New("Sperre wurde von einer anderen Sitzung übernommen")
+
+func lockDir() string { return filepath.Join(metaDir, "locks") }
+
+type lockInfo struct {
+	Key       string    `json:"key"`
+	User      string    `json:"user"`
+	Host      string    `json:"host"`
+	PID       int       `json:"pid"`
+	Started   time.Time `json:"started"`
+	Heartbeat time.Time `json:"heartbeat"`
+}
+
+func (li lockInfo) owner() string {
+	return li.User + "@" + li.Host
+}
+
+func (li lockInfo) same(o lockInfo) bool {
+	return li.Host == o.Host && li.PID == o.PID && li.Started.Equal(o.Started)
+}
+
+// stale: Prozess auf diesem Rechner beendet oder Heartbeat zu alt.
+func (li lockInfo) stale() bool {
+	if time.Since(li.Heartbeat) > lockStaleAfter {
+		return true
+	}
+	host, _ := os.Hostname()
+	return li.Host == host && !processAlive(li.PID)
+}
+
+// lockKey bestimmt, worum sich zwei Sitzungen streiten würden: die
+// Entwurfsdatei. Ein ADR und sein Entwurf teilen sich daher eine Sperre.
+func lockKey(o fileOption) string {
+	if o.Draft {
+		return filepath.Base(o.Path)
+	}
+	return draftName(o.Path)
+}
+
+func lockPath(key string) string {
+	return filepath.Join(lockDir(), strings.TrimSuffix(key, ".draft.json")+".lock")
+}
+
+func readLock(key string) (lockInfo, error) {
+	b, err := os.ReadFile(lockPath(key))
+	if err != nil {
+		return lockInfo{}, err
+	}
+	var li lockInfo
+	if err := json.Unmarshal(b, &li); err != nil {
+		return lockInfo{}, err
+	}
+	return li, nil
+}
+
+// readLocks liefert alle gültigen Sperren, verwaiste werden ignoriert.
+func readLocks() map[string]lockInfo {
+	out := map[string]lockInfo{}
+	entries, err := os.ReadDir(lockDir())
+	if err != nil {
+		return out
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".lock") {
+			continue
+		}
+		li, err := readLock(strings.TrimSuffix(e.Name(), ".lock") + ".draft.json")
+		if err == nil && !li.stale() {
+			out[li.Key] = li
+		}
+	}
+	return out
+}
+
+func newLockInfo(key, name string) lockInfo {
+	if name == "" {
+		if u, err := user.Current(); err == nil {
+			name = u.Username
+		}
+	}
+	host, _ := os.Hostname()
+	now := time.Now()
+	return lockInfo{Key: key, User: name, Host: host, PID: os.Getpid(), Started: now, Heartbeat: now}
+}
+
+// createLock legt die Sperrdatei exklusiv an; force überschreibt (Übernahme
+// oder verwaiste Sperre).
+func createLock(li lockInfo, force bool) error {
+	if _, err := ensureMetaDir("locks"); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(li, "", "  ")
+	if err != nil {
+		return err
+	}
+	if force {
+		return atomicWrite(lockPath(li.Key), b, 0o644)
+	}
+	f, err := os.OpenFile(lockPath(li.Key), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	_, werr := f.Write(b)
+	if cerr := f.Close(); werr == nil {
+		werr = cerr
+	}
+	return werr
+}
+
+// lockedByOther prüft direkt auf der Platte, ob eine fremde, lebende Sitzung
+// den Eintrag bearbeitet.
+func (m model) lockedByOther(o fileOption) (lockInfo, bool) {
+	if o.Path == newAdrSentinel || o.Rev != "" {
+		return lockInfo{}, false
+	}
+	li, err := readLock(lockKey(o))
+	if err != nil || li.stale() || li.same(m.lock) {
+		return lockInfo{}, false
+	}
+	return li, true
+}
+
+// acquireLock sperrt o für diese Sitzung. Verwaiste Sperren werden ersetzt.
+func (m *model) acquireLock(o fileOption) error {
+	return m.lockAs(lockKey(o), false)
+}
+
+// takeOverLock übernimmt eine fremde Sperre ausdrücklich.
+func (m *model) takeOverLock(o fileOption) error {
+	return m.lockAs(lockKey(o), true)
+}
+
+func (m *model) lockAs(key string, force bool) error {
+	li := newLockInfo(key, m.gitName)
+	err := createLock(li, force)
+	if errors.Is(err, os.ErrExist) {
+		cur, rerr := readLock(key)
+		if rerr == nil && cur.same(m.lock) {
+			return nil // bereits unsere (z. B. nach Übernahme)
+		}
+		if rerr == nil && !cur.stale() {
+			return fmt.Errorf("wird bearbeitet von %s", cur.owner())
+		}
+		err = createLock(li, true)
+	}
+	if err != nil {
+		return err
+	}
+	m.lock = li
+	return nil
+}
+
+// releaseLock entfernt die eigene Sperre – aber nur, wenn sie nicht
+// inzwischen übernommen wurde.
+func (m *model) releaseLock() {
+	if m.lock.Key == "" {
+		return
+	}
+	if cur, err := readLock(m.lock.Key); err == nil && cur.same(m.lock) {
+		_ = os.Remove(lockPath(m.lock.Key))
+	}
+	m.lock = lockInfo{}
+}
+
+// refreshLock schreibt den Heartbeat; errLockLost, wenn die Sperre jetzt
+// einer anderen Sitzung gehört.
+func refreshLock(li lockInfo) error {
+	if li.Key == "" {
+		return nil
+	}
+	if err := checkLock(li); err != nil {
+		return err
+	}
+	li.Heartbeat = time.Now()
+	return createLock(li, true)
+}
+
+// checkLock: errLockLost, wenn eine andere Sitzung die Sperre li
+// inzwischen übernommen hat.
+func checkLock(li lockInfo) error {
+	if li.Key == "" {
+		return nil
+	}
+	if cur, err := readLock(li.Key); err == nil && !cur.same(li) {
+		return fmt.Errorf("%w (%s)", errLockLost, cur.owner())
+	}
+	return nil
+}
+
+func lockChip(li lockInfo) string {
+	return chipBase.Background(lipgloss.Color(gbRed)).Render("wird bearbeitet von " + li.owner())
+}
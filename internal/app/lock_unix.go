@@ -0,0 +1,14 @@
+//go:build !windows
+
+package app
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processAlive prüft per Signal 0, ob der Prozess noch existiert.
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
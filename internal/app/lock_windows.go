@@ -0,0 +1,15 @@
+//go:build windows
+
+package app
+
+import "os"
+
+// processAlive: unter Windows schlägt FindProcess für beendete Prozesse fehl.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = p.Release()
+	return true
+}
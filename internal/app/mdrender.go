@@ -0,0 +1,142 @@
+package app
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+/* ------------------ Markdown fürs Terminal (Vorschau) --------------------- */
+
+var (
+	mdH1Style     = titleStyle.Foreground(lipgloss.Color(gbYellow))
+	mdH2Style     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(gbOrange))
+	mdH3Style     = lipgloss.NewStyle().Bold(true)
+	mdCodeStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(gbAqua))
+	mdBoldStyle   = lipgloss.NewStyle().Bold(true)
+	mdLinkStyle   = lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Color(gbBlue))
+	mdBulletStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(gbGray))
+	mdBorderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(gbGray))
+
+	mdListRe   = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdInlineRe = regexp.MustCompile("`([^`]+)`|\\*\\*([^*]+)\\*\\*|\\[([^\\]]+)\\]\\(([^)]+)\\)")
+	mdSepRe    = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+)
+
+// renderMarkdown setzt das Markdown, das ADRonaut schreibt, für das Terminal
+// um: Überschriften, Tabellen, Listen, Codeblöcke und die gängigen
+// Inline-Auszeichnungen. Fließtext wird auf width umbrochen.
+func renderMarkdown(md string, width int) string {
+	width = max(20, width)
+	lines := splitLines(strings.TrimRight(md, "\n"))
+	var out []string
+	blank := func() {
+		if len(out) > 0 && out[len(out)-1] != "" {
+			out = append(out, "")
+		}
+	}
+	for i := 0; i < len(lines); i++ {
+		t := strings.TrimSpace(lines[i])
+		switch {
+		case strings.HasPrefix(t, "```"):
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				out = append(out, mdCodeStyle.Render("  "+lines[i]))
+			}
+		case strings.HasPrefix(t, "|"):
+			var rows []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				rows = append(rows, strings.TrimSpace(lines[i]))
+			}
+			i--
+			out = append(out, strings.Split(renderMDTable(rows, width), "\n")...)
+		case strings.HasPrefix(t, "# "):
+			out = append(out, mdH1Style.Render(wrapMD(t[2:], width)))
+		case strings.HasPrefix(t, "## "):
+			blank()
+			out = append(out, mdH2Style.Render(t[3:]), mdBorderStyle.Render(strings.Repeat("─", min(width, lipgloss.Width(t[3:])))))
+		case strings.HasPrefix(t, "### "):
+			blank()
+			out = append(out, mdH3Style.Render(t[4:]))
+		case mdListRe.MatchString(lines[i]):
+			mm := mdListRe.FindStringSubmatch(lines[i])
+			indent := strings.Repeat(" ", len(strings.ReplaceAll(mm[1], "\t", "  ")))
+			marker := mm[2]
+			if strings.ContainsAny(marker, "-*+") {
+				marker = "•"
+			}
+			prefix := indent + mdBulletStyle.Render(marker) + " "
+			hang := strings.Repeat(" ", lipgloss.Width(prefix))
+			body := strings.Split(wrapMD(mm[3], width-lipgloss.Width(prefix)), "\n")
+			for k, l := range body {
+				if k == 0 {
+					out = append(out, prefix+l)
+				} else {
+					out = append(out, hang+l)
+				}
+			}
+		case t == "":
+			blank()
+		default:
+			out = append(out, strings.Split(wrapMD(t, width), "\n")...)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// wrapMD wendet die Inline-Auszeichnungen an und bricht auf width um.
+func wrapMD(s string, width int) string {
+	lines := strings.Split(lipgloss.NewStyle().Width(max(10, width)).Render(inlineMD(s)), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func inlineMD(s string) string {
+	return mdInlineRe.ReplaceAllStringFunc(s, func(x string) string {
+		mm := mdInlineRe.FindStringSubmatch(x)
+		switch {
+		case mm[1] != "":
+			return mdCodeStyle.Render(mm[1])
+		case mm[2] != "":
+			return mdBoldStyle.Render(mm[2])
+		}
+		return mdLinkStyle.Render(mm[3]) + mdBulletStyle.Render(" ("+mm[4]+")")
+	})
+}
+
+// renderMDTable zeichnet eine Markdown-Tabelle; die erste Zeile ist der Kopf.
+func renderMDTable(rows []string, width int) string {
+	var cells [][]string
+	for _, r := range rows {
+		if mdSepRe.MatchString(r) {
+			continue
+		}
+		r = strings.TrimSuffix(strings.TrimPrefix(r, "|"), "|")
+		var row []string
+		for _, c := range strings.Split(r, "|") {
+			row = append(row, inlineMD(strings.TrimSpace(c)))
+		}
+		cells = append(cells, row)
+	}
+	if len(cells) == 0 {
+		return ""
+	}
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(mdBorderStyle).
+		Headers(cells[0]...).
+		Rows(cells[1:]...).
+		StyleFunc(func(row, _ int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return mdBoldStyle.Padding(0, 1)
+			}
+			return lipgloss.NewStyle().Padding(0, 1)
+		})
+	if lipgloss.Width(t.String()) > width {
+		t = t.Width(width)
+	}
+	return t.String()
+}
@@ -1,11 +1,12 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
-	"path/filepath"
 	"strings"
 	"time"
 )
@@ -15,6 +16,9 @@ type fileOption struct {
 	Path  string
 	No    int
 	Draft bool
+	Rev   string // gesetzt für historische Einträge aus der Git-Historie
+	NS    string // Namensraum im Monorepo, leer im Haupt-Verzeichnis
+	Repo  string // Repository im Katalog
 }
 
 const newAdrSentinel = "__NEW_ADR__"
@@ -22,6 +26,7 @@ const newAdrSentinel = "__NEW_ADR__"
 type searchDoc struct {
 	Title, Status, Beteiligte, Tags                   string
 	Kontext, Entscheidung, Alternativen, Konsequenzen string
+	CreatedDate, LastEditedBy, LastEditedAt           string
 	Full                                              string // sämtlicher Text in Kleinbuchstaben für Volltext
 }
 
@@ -31,12 +36,17 @@ type model struct {
 	allOptions  []fileOption
 	pickOptions []fileOption
 	pickIdx     int
+	pickOffset  int // erste sichtbare Zeile der Liste
 	filter      textinput.Model
 	searchIndex map[string]string
 
 	createdDate  string
 	lastEditedBy string
 	lastEditedAt string
+	pruefregeln  string // "## Prüfregeln" wird nicht im Assistenten bearbeitet, nur mitgeschrieben
+	verweise     string // "## Verweise", ebenso
+	autor        string // Autor und Signing-Key aus der Datei; leer: aus git config
+	signingKey   string
 
 	gitName       string
 	gitEmail      string
@@ -47,12 +57,67 @@ type model struct {
 	hitSnippet map[string]string
 	lastQuery  string
 
+	historyOn      bool
+	historyLoading bool
+
+	sortCol       sortColumn
+	sortDesc      bool
+	groupByStatus bool
+
+	// Schnellaktionen
+	quick          quickKind
+	quickStatusIdx int
+	quickInput     textinput.Model
+	notice         string
+
+	stamps map[string]fileStamp // Stand für das Live-Reload
+	locks  map[string]lockInfo  // fremde Sperren, nach lockKey
+
+	// Sperre dieser Sitzung und ggf. angebotene Übernahme
+	lock         lockInfo
+	takeoverLock lockInfo
+	takeoverOpt  fileOption
+
+	// Mehrfachauswahl
+	selected map[string]bool
+	bulkOp   bulkOp
+	bulkPlan []bulkChange
+
+	drafts *draftsView // Entwürfe aufräumen (ALT+E)
+
 	// Edit-Kontext
 	editingPath    string
 	editingNo      int
 	draftFixedPath string
+	targetDir      string       // Zielverzeichnis beim Speichern, leer = adrDir
+	newRoot        string       // gewähltes ADR-Verzeichnis für neue ADRs, leer = defaultNewRoot
+	refPlan        *renamePlan  // Vorschau der Verweis-Anpassungen im Speicherdialog
+	readOnly       bool         // historischer Stand, Speichern gesperrt
+	viewRev        string       // Revision des historischen Stands
+	viewRepo       string       // Repository eines Katalog-Eintrags (nur lesen)
+	catalog        bool         // Picker über den Katalog statt über das Repository
+	codeRefs       codeRefIndex // Fundstellen im Quelltext, kommt asynchron
+
+	// Merge-Basis: Stand der Datei beim Laden
+	baseContent string
+	baseHash    string
+	baseModTime time.Time
+	merge       *mergeView
+
+	snaps *snapshotView // Versionshistorie des Entwurfs (F2)
+
+	// Rückgängig/Wiederholen
+	undoStack []editState
+	redoStack []editState
+	undoGroup string
+	undoAt    time.Time
 
 	step int
+	form bool // alle Felder auf einer Seite statt Schritt für Schritt (F3)
+
+	// Bearbeitung in $EDITOR (ALT+E)
+	extErr   error
+	extDraft string // nicht übernommener ADR-Text für den nächsten Versuch
 
 	// Inputs
 	title     textinput.Model
@@ -71,39 +136,73 @@ type model struct {
 	width  int
 	height int
 
-	saving     bool
-	err        error
-	confirming bool
+	saving      bool
+	err         error
+	confirming  bool
+	preview     viewport.Model // Vorschau im Speichern-Schritt, siehe previewPane
+	previewRaw  bool           // Markdown statt formatierter Darstellung
+	previewDiff bool           // Änderungen gegenüber der Datei statt Vorschau
+	diff        *savedDiff     // zwischengespeichertes saveDiff, siehe refreshSaveDiff
 }
 
 func initialModel() model {
-	m := model{}
+	m := newBlankModel()
 
-	opts := scanADRFiles(".")
-	drafts := scanDrafts(".")
+	opts := scanAllADRFiles()
+	drafts := scanDrafts()
 	all := make([]fileOption, 0, 1+len(drafts)+len(opts))
 	all = append(all, fileOption{Label: "➕ Neuer ADR", Path: newAdrSentinel, No: 0})
 	all = append(all, drafts...)
 	all = append(all, opts...)
 	m.allOptions = all
 	m.searchDocs = buildSearchDocs(all)
+	m.stamps = snapshotFiles()
+	m.locks = readLocks()
+	m.notice = startupNotice
 	//	m.searchIndex = buildSearchIndex(all)
 	m.pickOptions = all
 	m.startup = true
 	m.pickIdx = 0
 
-	// Suchfeld
+	m.filter = newSearchField()
+	m.form = loadPrefs().Editor == editorForm
+
+	m.applyFilter("") // initial alle anzeigen
+	m.startup = true
+	m.pickIdx = 0
+
+	// Wenn es weder ADR-Dateien noch Drafts gibt -> direkt in den Editor springen
+	if len(opts) == 0 && len(drafts) == 0 {
+		m.startup = false
+		m.editingPath = ""
+		m.editingNo = 0
+		m.draftFixedPath = newDraftPath() // gesperrt wird erst in Run, headless nicht
+		m.step = 0
+		_ = m.title.Focus() // Cursor direkt in den Titel
+	} else {
+		// Nur wenn wir den Picker zeigen, die Suche befüllen
+		m.applyFilter("") // initial alle anzeigen
+		m.startup = true
+		m.pickIdx = 0
+	}
+
+	return m
+}
+
+func newSearchField() textinput.Model {
 	f := textinput.New()
 	f.Placeholder = "Tippen zum Filtern (Titel/Tags/Inhalt)"
 	f.Prompt = "🔎 "
 	f.CharLimit = 256
 	f.Width = 40
-	m.filter = f
-	m.filter.Focus()
+	f.Focus()
+	return f
+}
 
-	m.applyFilter("") // initial alle anzeigen
-	m.startup = true
-	m.pickIdx = 0
+// newBlankModel baut die Eingabefelder des Editors ohne Picker-Daten auf.
+// Schnellaktionen nutzen es, um einen ADR ohne UI zu laden und zu speichern.
+func newBlankModel() model {
+	m := model{}
 
 	t := textinput.New()
 	t.Placeholder = "Kurzer Titel, z. B. \"Wahl des Service Mesh\""
@@ -138,34 +237,46 @@ func initialModel() model {
 	tg.Width = 80
 	m.tags = tg
 
-	m.statusIdx = 0 // Vorgeschlagen
-
-	// Wenn es weder ADR-Dateien noch Drafts gibt -> direkt in den Editor springen
-	if len(opts) == 0 && len(drafts) == 0 {
-		m.startup = false
-		m.editingPath = ""
-		m.editingNo = 0
-		m.draftFixedPath = filepath.Join(autosaveDir, fmt.Sprintf("new-%d.draft.json", time.Now().UnixNano()))
-		m.step = 0
-		_ = m.title.Focus() // Cursor direkt in den Titel
-	} else {
-		// Nur wenn wir den Picker zeigen, die Suche befüllen
-		m.applyFilter("") // initial alle anzeigen
-		m.startup = true
-		m.pickIdx = 0
-	}
+	m.preview = viewport.New(w, 0)
 
+	m.statusIdx = 0 // Vorgeschlagen
 	return m
 }
 
 func (m model) Init() tea.Cmd {
+	var mouse tea.Cmd
+	if m.form {
+		mouse = tea.EnableMouseCellMotion
+	}
 	if m.startup {
-		return tea.Batch(textinput.Blink, loadGitInfoCmd())
+		return tea.Batch(textinput.Blink, loadGitInfoCmd(), scheduleWatch(), loadCodeRefsCmd(m.catalog), mouse)
 	}
-	return tea.Batch(textinput.Blink, m.focusForStep(), scheduleAutosave(), loadGitInfoCmd())
+	return tea.Batch(textinput.Blink, m.focusForStep(), scheduleAutosave(), loadGitInfoCmd(), mouse)
 }
 
+// Update fängt im Editor Rückgängig/Wiederholen ab und merkt sich den Stand
+// vor jeder Taste, die Felder verändert.
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	km, ok := msg.(tea.KeyMsg)
+	if !ok || !m.undoable() {
+		return m.update(msg)
+	}
+	switch km.String() {
+	case "ctrl+z":
+		return m.undo()
+	case "ctrl+y":
+		return m.redo()
+	}
+	before := m.editState()
+	res, cmd := m.update(msg)
+	if nm, ok := res.(model); ok {
+		nm.trackUndo(before, km)
+		return nm, cmd
+	}
+	return res, cmd
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch mm := msg.(type) {
 
 	case gitInfoLoadedMsg:
@@ -174,27 +285,68 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.gitSigningKey = mm.signingKey
 		return m, nil
 
+	case codeRefsLoadedMsg:
+		m.codeRefs = mm.refs
+		return m, nil
+
+	case externalEditDoneMsg:
+		return m.applyExternalEdit(mm)
+
 	case saveDoneMsg:
 		return m.handleSaveDone(mm)
 
-	case tea.WindowSizeMsg:
-		m.width, m.height = mm.Width, mm.Height
-		w := max(50, m.width-2*framePadding)
+	case quickActionDoneMsg:
+		return m.handleQuickDone(mm), nil
+
+	case bulkDoneMsg:
+		return m.handleBulkDone(mm), nil
+
+	case watchTickMsg:
+		if !m.startup || m.catalog {
+			return m, nil // im Editor nicht mehr nötig
+		}
+		return m, watchCmd(m.stamps)
 
-		m.kontext.SetWidth(w)
-		m.entscheidung.setWidthAll(w)
-		m.konsequenzen.setWidthAll(w)
-		m.alternativen.setWidthAll(w)
+	case filesChangedMsg:
+		if !m.startup {
+			return m, nil
+		}
+		return m.applyFileChanges(mm), scheduleWatch()
+
+	case historyLoadedMsg:
+		m.historyLoading = false
+		if mm.err != nil {
+			m.err = fmt.Errorf("Historie konnte nicht geladen werden: %w", mm.err)
+			m.historyOn = false
+			return m, nil
+		}
+		if m.historyOn {
+			m.setHistory(mm.opts, mm.docs)
+		}
+		return m, nil
 
-		m.title.Width = w
-		m.beteiligte.Width = w
-		m.tags.Width = w
+	case tea.WindowSizeMsg:
+		m.width, m.height = mm.Width, mm.Height
+		m.resizeFields()
+		m.scrollPick()
 		return m, nil
 
+	case tea.MouseMsg:
+		return m.updateMouse(mm)
+
 	case tea.KeyMsg:
 		// --- Startup Picker ---
 		// --- Startup Picker ---
 		if m.startup {
+			if m.drafts != nil {
+				return m.updateDrafts(mm)
+			}
+			if m.quick != quickNone {
+				return m.updateQuick(mm)
+			}
+			if m.catalog && m.catalogKeyBlocked(mm.String()) {
+				return m, nil
+			}
 			// Navigation/Fokuswechsel
 			switch mm.String() {
 			case "tab":
@@ -209,6 +361,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// in der Liste weiter nach unten
 				if len(m.pickOptions) > 0 {
 					m.pickIdx = (m.pickIdx + 1) % len(m.pickOptions)
+					m.scrollPick()
 				}
 				return m, nil
 
@@ -225,6 +378,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "down", "ctrl+n":
 				if !m.filter.Focused() && len(m.pickOptions) > 0 {
 					m.pickIdx = (m.pickIdx + 1) % len(m.pickOptions)
+					m.scrollPick()
 				}
 				return m, nil
 
@@ -234,38 +388,83 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if m.pickIdx < 0 {
 						m.pickIdx = len(m.pickOptions) - 1
 					}
+					m.scrollPick()
 				}
 				return m, nil
 
+			case "pgdown":
+				m.filter.Blur()
+				m.movePick(m.pickListHeight() - 1)
+				return m, nil
+
+			case "pgup":
+				m.filter.Blur()
+				m.movePick(-(m.pickListHeight() - 1))
+				return m, nil
+
+			case "home", "end":
+				// im Suchfeld bewegen POS1/ENDE den Cursor
+				if !m.filter.Focused() {
+					if mm.String() == "home" {
+						m.movePick(-len(m.pickOptions))
+					} else {
+						m.movePick(len(m.pickOptions))
+					}
+					return m, nil
+				}
+
+			case "alt+s", "alt+t", "alt+d", "alt+a", "alt+x", "alt+n", "alt+b":
+				return m.startQuick(mm.String())
+
+			case " ", "space":
+				if !m.filter.Focused() {
+					m.toggleSelected()
+					m.movePick(1)
+					return m, nil
+				}
+
+			case "alt+m":
+				m.toggleSelectAll()
+				return m, nil
+
+			case "alt+o", "alt+r", "alt+g":
+				switch mm.String() {
+				case "alt+o":
+					m.sortCol = (m.sortCol + 1) % sortColumnCount
+				case "alt+r":
+					m.sortDesc = !m.sortDesc
+				case "alt+g":
+					m.groupByStatus = !m.groupByStatus
+				}
+				m.applyFilter(m.filter.Value())
+				m.scrollPick()
+				return m, nil
+
+			case "alt+e":
+				return m.openDrafts()
+
+			case "alt+h":
+				m.historyOn = !m.historyOn
+				if !m.historyOn {
+					m.setHistory(nil, nil)
+					return m, nil
+				}
+				m.historyLoading = true
+				return m, loadHistoryCmd()
+
 			case "enter":
-				choice := m.pickOptions[m.pickIdx]
-				if choice.Path == newAdrSentinel {
-					m.startup = false
-					m.editingPath = ""
-					m.editingNo = 0
-					m.draftFixedPath = filepath.Join(autosaveDir, fmt.Sprintf("new-%d.draft.json", time.Now().UnixNano()))
-					m.step = 0
-					return m, tea.Batch(m.focusForStep(), scheduleAutosave())
+				if len(m.pickOptions) == 0 {
+					return m, nil
 				}
-				if choice.Draft {
-					if err := m.loadDraft(choice.Path); err != nil {
-						m.err = fmt.Errorf("Konnte Entwurf nicht laden: %w", err)
-						return m, nil
-					}
-					m.draftFixedPath = choice.Path
-					m.startup = false
-					m.step = 0
-					return m, tea.Batch(m.focusForStep(), scheduleAutosave())
+				choice := m.pickOptions[m.pickIdx]
+				if m.catalog {
+					return m.openCatalogOption(choice)
 				}
-				if err := m.loadFromFile(choice.Path); err != nil {
-					m.err = fmt.Errorf("Konnte Datei nicht laden: %w", err)
+				if li, held := m.lockedByOther(choice); held {
+					m.offerTakeover(choice, li)
 					return m, nil
 				}
-				m.draftFixedPath = ""
-				m.startup = false
-				m.editingPath = choice.Path
-				m.step = 0
-				return m, tea.Batch(m.focusForStep(), scheduleAutosave())
+				return m.openOption(choice)
 
 			case "esc", "ctrl+c":
 				return m, tea.Quit
@@ -283,11 +482,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.filter.Value() != old {
 				m.applyFilter(m.filter.Value())
 				m.pickIdx = 0
+				m.scrollPick()
 			}
 			return m, cmd
 
 		}
 
+		if m.merge != nil {
+			return m.updateMerge(mm)
+		}
+		if m.snaps != nil {
+			return m.updateSnapshots(mm)
+		}
+		if mm.String() == "f2" && !m.readOnly {
+			return m.openSnapshots()
+		}
+		m.extErr = nil
+		if mm.String() == "f3" {
+			return m, m.toggleForm()
+		}
+		if k := mm.String(); (k == "alt+e" || k == "alt+E") && !m.readOnly && !m.saving && !m.confirming {
+			return m, m.openExternalEditor(k == "alt+E")
+		}
+		if cmd, ok := m.jumpTo(mm.String()); ok {
+			return m, cmd
+		}
+
 		// --- Schritte mit TAB/SHIFT+TAB ---
 		switch mm.String() {
 		case "tab":
@@ -321,11 +541,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if m.catalog && mm.Type == tea.KeyEsc {
+			return m.backToCatalog()
+		}
 		switch mm.Type {
 		case tea.KeyCtrlC, tea.KeyEsc:
 			return m, tea.Quit
 		}
 
+		if m.step == 8 && !m.saving && m.previewKey(mm.String()) {
+			return m, nil
+		}
+
+		// Historische Stände: nur Navigation zwischen den Schritten
+		if m.readOnly {
+			switch mm.String() {
+			case "space", "enter":
+				if m.step < 8 {
+					m.step++
+					return m, m.focusForStep()
+				}
+			}
+			return m, nil
+		}
+
 		switch mm.String() {
 		case "space", "enter":
 			if m.step == 1 {
@@ -343,6 +582,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "s":
 				if !m.confirming {
 					m.confirming = true
+					if m.editingPath != "" {
+						m.previewDiff = true
+						m.preview.GotoTop()
+						m.refreshSaveDiff()
+					}
+					m.refPlan = nil
+					if op, ok := m.pendingRename(); ok {
+						if plan, err := planRenames([]renameOp{op}); err == nil {
+							m.refPlan = &plan
+						}
+					}
 					return m, nil
 				}
 				m.saving = true
@@ -364,6 +614,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				m.step = 7
 				return m, m.focusForStep()
+			case "z":
+				if m.canChooseRoot() {
+					m.cycleNewRoot()
+				}
+				return m, nil
 			}
 		}
 	}
@@ -373,7 +628,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case autosaveTickMsg:
 		return m, tea.Batch(autosaveCmd(m), scheduleAutosave())
 	case autosaveDoneMsg:
-		_ = msg
+		if errors.Is(msg.err, errLockLost) {
+			m.err = fmt.Errorf("Autosave pausiert: %w", msg.err)
+		}
 		return m, nil
 	}
 
@@ -410,7 +667,78 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// openOption öffnet einen Picker-Eintrag im Editor.
+func (m model) openOption(o fileOption) (model, tea.Cmd) {
+	if o.Path == newAdrSentinel {
+		m.startup = false
+		m.editingPath = ""
+		m.editingNo = 0
+		m.draftFixedPath = newDraftPath()
+		_ = m.acquireLock(fileOption{Path: m.draftFixedPath, Draft: true})
+		m.step = 0
+		return m, tea.Batch(m.focusForStep(), scheduleAutosave())
+	}
+	if o.Rev != "" {
+		if err := m.loadFromRevision(o); err != nil {
+			m.err = fmt.Errorf("Konnte historischen Stand nicht laden: %w", err)
+			return m, nil
+		}
+		m.startup = false
+		m.step = 0
+		return m, m.focusForStep()
+	}
+	if err := m.acquireLock(o); err != nil {
+		m.err = fmt.Errorf("Konnte Sperre nicht setzen: %w", err)
+		return m, nil
+	}
+	if o.Draft {
+		if err := m.loadDraft(o.Path); err != nil {
+			m.releaseLock()
+			m.err = fmt.Errorf("Konnte Entwurf nicht laden: %w", err)
+			return m, nil
+		}
+		m.draftFixedPath = o.Path
+		m.loadUndo()
+		m.startup = false
+		m.step = 0
+		return m, tea.Batch(m.focusForStep(), scheduleAutosave())
+	}
+	if err := m.loadFromFile(o.Path); err != nil {
+		m.releaseLock()
+		m.err = fmt.Errorf("Konnte Datei nicht laden: %w", err)
+		return m, nil
+	}
+	m.draftFixedPath = ""
+	m.startup = false
+	m.editingPath = o.Path
+	m.loadUndo()
+	m.step = 0
+	return m, tea.Batch(m.focusForStep(), scheduleAutosave())
+}
+
+// resizeFields passt die Eingabefelder an die Fensterbreite an; im Formular
+// bleibt links Platz für das Inhaltsverzeichnis.
+func (m *model) resizeFields() {
+	if m.width == 0 {
+		return
+	}
+	w := max(50, m.width-2*framePadding)
+	if m.form {
+		w = m.formFieldWidth()
+	}
+
+	m.kontext.SetWidth(w)
+	m.entscheidung.setWidthAll(w)
+	m.konsequenzen.setWidthAll(w)
+	m.alternativen.setWidthAll(w)
+
+	m.title.Width = w
+	m.beteiligte.Width = w
+	m.tags.Width = w
+}
+
 func (m *model) focusForStep() tea.Cmd {
+	m.refreshSaveDiff()
 	m.title.Blur()
 	m.beteiligte.Blur()
 	m.tags.Blur()
@@ -440,6 +768,8 @@ func (m *model) focusForStep() tea.Cmd {
 		return m.beteiligte.Focus()
 	case 7:
 		return m.tags.Focus()
+	case 8:
+		m.preview.GotoTop()
 	}
 	return nil
 }
@@ -0,0 +1,293 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/* ------------- Nummernvergabe ohne Kollisionen zwischen Branches ---------- */
+
+const (
+	numberingLocal     = "local"     // höchste Nummer im Arbeitsverzeichnis + 1
+	numberingGit       = "git"       // zusätzlich alle lokalen und Remote-Tracking-Branches
+	numberingTimestamp = "timestamp" // JJJJMMTTHHMM statt fortlaufender Nummer
+
+	// reservationsFile liegt im ADR-Verzeichnis und wird mit eingecheckt;
+	// je Zeile eine reservierte Nummer.
+	reservationsFile = ".adr-reservations"
+)
+
+// numbering wird von initWorkspace aus der Konfiguration gesetzt.
+var numbering = numberingLocal
+
+func checkNumbering(s string) (string, error) {
+	switch strings.ToLower(s) {
+	case "", numberingLocal:
+		return numberingLocal, nil
+	case numberingGit, numberingTimestamp:
+		return strings.ToLower(s), nil
+	}
+	return "", fmt.Errorf("%s: unbekannter Wert %q für \"numbering\" (erlaubt: local, git, timestamp)", configFile, s)
+}
+
+// adrNumber liest die Nummer aus einem Dateinamen wie ADR-0042-titel.md.
+func adrNumber(name string) (int, bool) {
+	mm := adrFileRe.FindStringSubmatch(name)
+	if len(mm) != 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(mm[1])
+	return n, err == nil
+}
+
+type reservation struct {
+	No    int
+	User  string
+	Date  string
+	Title string
+}
+
+func parseReservations(b []byte) []reservation {
+	var out []reservation
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f := strings.Split(line, "|")
+		n, err := strconv.Atoi(strings.TrimSpace(f[0]))
+		if err != nil {
+			continue
+		}
+		r := reservation{No: n}
+		for i, p := range []*string{&r.User, &r.Date, &r.Title} {
+			if i+1 < len(f) {
+				*p = strings.TrimSpace(f[i+1])
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func readReservations(dir string) []reservation {
+	b, err := os.ReadFile(filepath.Join(dir, reservationsFile))
+	if err != nil {
+		return nil
+	}
+	return parseReservations(b)
+}
+
+// usedNumbers sammelt alle Nummern, die in dir (samt archiv/) schon
+// vergeben oder reserviert sind – mit numberingGit auch auf allen Branches.
+func usedNumbers(dir string) (map[int]bool, error) {
+	used := map[int]bool{}
+	for _, d := range []string{dir, filepath.Join(dir, archiveDir)} {
+		entries, err := os.ReadDir(d)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		for _, e := range entries {
+			if n, ok := adrNumber(e.Name()); ok && !e.IsDir() {
+				used[n] = true
+			}
+		}
+	}
+	for _, r := range readReservations(dir) {
+		used[r.No] = true
+	}
+	if numbering == numberingGit {
+		if err := gitUsedNumbers(dir, used); err != nil {
+			return nil, err
+		}
+	}
+	return used, nil
+}
+
+// gitUsedNumbers ergänzt used um die Nummern (und Reservierungen) auf allen
+// lokalen und Remote-Tracking-Branches. Ohne Git bleibt used unverändert.
+func gitUsedNumbers(dir string, used map[int]bool) error {
+	if gitToplevel() == "" {
+		return nil
+	}
+	out, err := exec.Command("git", "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes").Output()
+	if err != nil {
+		return fmt.Errorf("git for-each-ref: %w", err)
+	}
+	// Pfade relativ zur Repo-Wurzel; liegen die ADRs dort, ist es ".", denn
+	// ein leerer Pfad ist für git kein gültiges Pathspec.
+	base := filepath.ToSlash(filepath.Clean(dir))
+	archive := path.Join(base, archiveDir) + "/"
+	if base != "." {
+		base += "/"
+	}
+	for _, ref := range strings.Fields(string(out)) {
+		if strings.HasSuffix(ref, "/HEAD") {
+			continue
+		}
+		// Fehlt das Verzeichnis auf einem Branch, listet ls-tree einfach
+		// nichts; ein Fehler ist also ein echter.
+		names, err := exec.Command("git", "ls-tree", "--name-only", ref, base, archive).Output()
+		if err != nil {
+			return fmt.Errorf("git ls-tree %s: %s", ref, gitErrText(err))
+		}
+		for _, p := range strings.Split(string(names), "\n") {
+			if n, ok := adrNumber(filepath.Base(p)); ok {
+				used[n] = true
+			}
+			if filepath.Base(p) == reservationsFile {
+				if b, err := gitShow(ref + ":" + p); err == nil {
+					for _, r := range parseReservations([]byte(b)) {
+						used[r.No] = true
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// gitErrText: die Fehlermeldung von git statt nur des Exit-Codes.
+func gitErrText(err error) string {
+	var ee *exec.ExitError
+	if errors.As(err, &ee) && len(ee.Stderr) > 0 {
+		return strings.TrimSpace(string(ee.Stderr))
+	}
+	return err.Error()
+}
+
+// nextADRNumber liefert die nächste freie Nummer in dir gemäß numbering.
+func nextADRNumber(dir string) (int, error) {
+	used, err := usedNumbers(dir)
+	if err != nil {
+		return 0, err
+	}
+	return nextFree(used), nil
+}
+
+func nextFree(used map[int]bool) int {
+	if numbering == numberingTimestamp {
+		n, _ := strconv.Atoi(time.Now().Format("200601021504"))
+		for used[n] {
+			n++
+		}
+		return n
+	}
+	top := 0
+	for n := range used {
+		top = max(top, n)
+	}
+	return top + 1
+}
+
+// allocateNumber nimmt für einen neuen ADR zuerst eine offene Reservierung
+// von who, sonst die nächste freie Nummer.
+func allocateNumber(dir, who string) (int, error) {
+	if who = strings.TrimSpace(who); who == "" {
+		who = osUser()
+	}
+	taken := map[int]bool{}
+	if entries, err := os.ReadDir(dir); err == nil {
+		for _, e := range entries {
+			if n, ok := adrNumber(e.Name()); ok {
+				taken[n] = true
+			}
+		}
+	}
+	best := 0
+	for _, r := range readReservations(dir) {
+		if strings.EqualFold(r.User, who) && !taken[r.No] && (best == 0 || r.No < best) {
+			best = r.No
+		}
+	}
+	if best > 0 {
+		return best, nil
+	}
+	return nextADRNumber(dir)
+}
+
+func osUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
+
+/* --------------------------- adronaut reserve ----------------------------- */
+
+func runReserve(args []string) error {
+	fs := flag.NewFlagSet("reserve", flag.ContinueOnError)
+	ns := fs.String("ns", "", "Namensraum im Monorepo (Standard: Verzeichnis des aktuellen Teilbaums)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Benutzung: adronaut reserve [--ns namensraum] [titel]")
+		fs.PrintDefaults()
+	}
+	words, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if numbering == numberingTimestamp {
+		return errors.New("reserve: bei \"numbering\": \"timestamp\" sind Reservierungen unnötig")
+	}
+	r := defaultNewRoot()
+	if *ns != "" {
+		var ok bool
+		if r, ok = rootByNS(*ns); !ok {
+			return fmt.Errorf("unbekannter Namensraum %q", *ns)
+		}
+	}
+	no, err := nextADRNumber(r.Dir)
+	if err != nil {
+		return err
+	}
+	who := strings.TrimSpace(gitConfig("user.name"))
+	if who == "" {
+		who = osUser()
+	}
+	title := strings.Join(words, " ")
+	path := filepath.Join(r.Dir, reservationsFile)
+	old, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(old) == 0 {
+		old = []byte("# Reservierte ADR-Nummern – Nummer | Person | Datum | Titel\n# Einchecken und pushen, damit andere Branches sie sehen.\n")
+	}
+	line := fmt.Sprintf("%04d | %s | %s | %s\n", no, who, time.Now().Format("2006-01-02"), title)
+	if err := atomicWrite(path, append(old, line...), 0o644); err != nil {
+		return err
+	}
+	fmt.Println(okStyle.Render("✔ reserviert: ") + adrID(r.NS, no) + " für " + who)
+	fmt.Println(helpStyle.Render(path + " einchecken und pushen; der nächste neue ADR von " + who + " bekommt diese Nummer."))
+	return nil
+}
+
+func rootByNS(ns string) (adrRoot, bool) {
+	for _, r := range adrRoots {
+		if r.NS == ns {
+			return r, true
+		}
+	}
+	return adrRoot{}, false
+}
+
+// gitConfig liest einen Wert aus der Git-Konfiguration; leer bei Fehler.
+func gitConfig(key string) string {
+	out, err := exec.Command("git", "config", "--get", key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
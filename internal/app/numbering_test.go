@@ -0,0 +1,59 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func runGit(t *testing.T, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@x", "-c", "commit.gpgsign=false"}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// Auf anderen Branches vergebene Nummern zählen mit – auch archivierte und
+// auch dann, wenn die ADRs direkt in der Repo-Wurzel liegen.
+func TestGitUsedNumbers(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git fehlt")
+	}
+	for _, dir := range []string{".", filepath.Join("docs", "adr")} {
+		t.Run(dir, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			touch := func(name string) {
+				t.Helper()
+				p := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(p, []byte("# x\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			runGit(t, "init", "-q", "-b", "main")
+			touch("ADR-0001-a.md")
+			runGit(t, "add", ".")
+			runGit(t, "commit", "-qm", "a")
+			runGit(t, "checkout", "-qb", "feature")
+			touch("ADR-0002-b.md")
+			touch(filepath.Join(archiveDir, "ADR-0003-c.md"))
+			runGit(t, "add", ".")
+			runGit(t, "commit", "-qm", "b")
+			runGit(t, "checkout", "-q", "main")
+
+			used := map[int]bool{}
+			if err := gitUsedNumbers(dir, used); err != nil {
+				t.Fatal(err)
+			}
+			for _, n := range []int{1, 2, 3} {
+				if !used[n] {
+					t.Errorf("number %d not counted: %v", n, used)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+/* ----------------- Picker: Fensterung der Liste + Vorschau ---------------- */
+
+const (
+	previewMinWidth   = 100 // ab dieser Terminalbreite wird die Vorschau gezeigt
+	previewMaxKontext = 12
+	previewMaxRefs    = 3
+)
+
+// pickListHeight liefert die Anzahl sichtbarer Listenzeilen. Solange keine
+// WindowSizeMsg eingetroffen ist, wird alles gezeigt. Kopf und Fuß werden
+// gerendert und gemessen; die Zeile nach dem letzten "\n" des Kopfes ist der
+// Tabellenkopf.
+func (m model) pickListHeight() int {
+	if m.height <= 0 {
+		return len(m.pickOptions)
+	}
+	return max(3, m.height-lipgloss.Height(m.pickerHead())-lipgloss.Height(m.pickerFoot()))
+}
+
+// scrollPick verschiebt das Fenster so, dass pickIdx sichtbar bleibt.
+func (m *model) scrollPick() {
+	h := m.pickListHeight() - 1 // eine Zeile für das Snippet der Auswahl
+	if h < 1 {
+		h = 1
+	}
+	if m.pickIdx < m.pickOffset {
+		m.pickOffset = m.pickIdx
+	}
+	if m.pickIdx >= m.pickOffset+h {
+		m.pickOffset = m.pickIdx - h + 1
+	}
+	if maxOff := len(m.pickOptions) - h; m.pickOffset > maxOff {
+		m.pickOffset = max(0, maxOff)
+	}
+	if m.pickOffset < 0 {
+		m.pickOffset = 0
+	}
+}
+
+// movePick bewegt die Auswahl um delta Zeilen (ohne Umlauf).
+func (m *model) movePick(delta int) {
+	if len(m.pickOptions) == 0 {
+		return
+	}
+	m.pickIdx += delta
+	if m.pickIdx < 0 {
+		m.pickIdx = 0
+	}
+	if m.pickIdx >= len(m.pickOptions) {
+		m.pickIdx = len(m.pickOptions) - 1
+	}
+	m.scrollPick()
+}
+
+func (m model) previewWidth() int {
+	if m.width < previewMinWidth {
+		return 0
+	}
+	return m.width * 2 / 5
+}
+
+// renderPreview zeigt Metadaten und Kontext des ausgewählten ADR.
+func (m model) renderPreview(w, h int) string {
+	if len(m.pickOptions) == 0 || m.pickIdx >= len(m.pickOptions) {
+		return ""
+	}
+	opt := m.pickOptions[m.pickIdx]
+	box := lipgloss.NewStyle().
+		Width(w-2).
+		Height(max(1, h-2)).
+		MaxHeight(h).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(gbGray)).
+		Padding(0, 1)
+
+	if opt.Path == newAdrSentinel {
+		return box.Render(helpStyle.Render("Neuen ADR im Assistenten anlegen."))
+	}
+	doc := m.searchDocs[opt.Path]
+
+	var b strings.Builder
+	title := doc.Title
+	if title == "" {
+		title = opt.Label
+	}
+	b.WriteString(titleStyle.Render(title) + "\n\n")
+
+	row := func(k, v string) {
+		if strings.TrimSpace(v) == "" {
+			return
+		}
+		fmt.Fprintf(&b, "%s %s\n", labelStyle.Render(k+":"), v)
+	}
+	row("Repository", opt.Repo)
+	row("Status", doc.Status)
+	row("Erstellt", doc.CreatedDate)
+	if doc.LastEditedAt != "" || doc.LastEditedBy != "" {
+		row("Zuletzt", strings.TrimSpace(doc.LastEditedAt+" "+doc.LastEditedBy))
+	}
+	row("Beteiligte", doc.Beteiligte)
+	row("Tags", doc.Tags)
+	if opt.Rev != "" {
+		row("Revision", shortRev(opt.Rev))
+	}
+	if opt.Draft {
+		row("Entwurf", opt.Path)
+	}
+	if refs := m.codeRefs.of(opt); len(refs) > 0 {
+		row("Im Code", fmt.Sprintf("%d Fundstelle(n)", len(refs)))
+		if staleStatus(doc.Status) {
+			b.WriteString(errorStyle.Render("⚠ "+doc.Status+", wird aber noch zitiert") + "\n")
+		}
+		for i, r := range refs {
+			if i == previewMaxRefs {
+				b.WriteString(helpStyle.Render("  …") + "\n")
+				break
+			}
+			b.WriteString(truncateLine(helpStyle.Render("  "+r.String()), w-4) + "\n")
+		}
+	}
+
+	b.WriteString("\n" + labelStyle.Render("Kontext") + "\n")
+	k := strings.TrimSpace(doc.Kontext)
+	if k == "" {
+		k = "(noch offen)"
+	}
+	lines := strings.Split(k, "\n")
+	if len(lines) > previewMaxKontext {
+		lines = append(lines[:previewMaxKontext], "…")
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+
+	return box.Render(b.String())
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+/* ------------------ Einstellungen, die ADRonaut sich merkt ----------------- */
+
+const editorForm = "formular" // sonst Assistent (Schritt für Schritt)
+
+type prefs struct {
+	Editor string `json:"editor,omitempty"`
+}
+
+func prefsPath() (string, error) {
+	dir, err := configHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "adronaut", "prefs.json"), nil
+}
+
+// loadPrefs liefert bei fehlender oder kaputter Datei die Vorgaben.
+func loadPrefs() prefs {
+	var p prefs
+	path, err := prefsPath()
+	if err != nil {
+		return p
+	}
+	if b, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(b, &p)
+	}
+	return p
+}
+
+func savePrefs(p prefs) error {
+	path, err := prefsPath()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicWrite(path, b, 0o644)
+}
@@ -0,0 +1,493 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ------------- Schnellaktionen im Picker (ohne den Assistenten) ----------- */
+
+const archiveDir = "archiv"
+
+type quickKind int
+
+const (
+	quickNone quickKind = iota
+	quickStatus
+	quickTags
+	quickArchive
+	quickDiscard
+	quickRenameTag
+	quickRenameParticipant
+	quickBulkConfirm
+	quickTakeover
+)
+
+type quickActionDoneMsg struct {
+	oldPath string
+	newPath string // leer, wenn der Eintrag verschwindet
+	notice  string
+	err     error
+}
+
+// editOption lädt einen ADR oder Entwurf in ein leeres Modell, wendet edit an
+// und speichert über denselben Weg wie der Assistent (writeADR bzw. writeDraft).
+func (m model) editOption(o fileOption, edit func(*model)) (string, error) {
+	e, err := m.loadOption(o)
+	if err != nil {
+		return "", err
+	}
+	if err := e.checkSections(); err != nil {
+		return "", err
+	}
+	edit(&e)
+	if o.Draft {
+		return o.Path, writeDraft(o.Path, e.toDraft())
+	}
+	return writeADR(e)
+}
+
+// loadOption lädt einen ADR oder Entwurf in ein leeres Modell mit den
+// Git-Angaben von m.
+func (m model) loadOption(o fileOption) (model, error) {
+	e := newBlankModel()
+	e.gitName, e.gitEmail, e.gitSigningKey = m.gitName, m.gitEmail, m.gitSigningKey
+	if o.Draft {
+		if err := e.loadDraft(o.Path); err != nil {
+			return e, err
+		}
+		e.draftFixedPath = o.Path
+		return e, nil
+	}
+	if err := e.loadFromFile(o.Path); err != nil {
+		return e, err
+	}
+	e.editingPath = o.Path
+	return e, nil
+}
+
+// checkSections verweigert das Speichern eines ADRs mit eigenen Abschnitten,
+// die der Weg über buildMarkdown verwerfen würde.
+func (m model) checkSections() error {
+	if m.editingPath == "" {
+		return nil
+	}
+	if hs := unknownSections(m.baseContent); len(hs) > 0 {
+		return fmt.Errorf("%s: Abschnitt „## %s“ ginge beim Speichern verloren – bitte im Editor bearbeiten",
+			m.editingPath, strings.Join(hs, "“, „## "))
+	}
+	return nil
+}
+
+func quickEditCmd(m model, o fileOption, notice string, edit func(*model)) tea.Cmd {
+	return func() tea.Msg {
+		p, err := m.editOption(o, edit)
+		return quickActionDoneMsg{oldPath: o.Path, newPath: p, notice: notice, err: err}
+	}
+}
+
+// duplicateCmd legt eine Kopie als neuen Entwurf an (ohne Nummer/Datum).
+func duplicateCmd(m model, o fileOption) tea.Cmd {
+	return func() tea.Msg {
+		e, err := m.loadOption(o)
+		if err != nil {
+			return quickActionDoneMsg{oldPath: o.Path, newPath: o.Path, err: err}
+		}
+		e.draftFixedPath = ""
+		e.editingPath = ""
+		e.editingNo = 0
+		e.createdDate = ""
+		e.title.SetValue("Kopie von " + e.Title())
+		dp := newDraftPath()
+		if err := writeDraft(dp, e.toDraft()); err != nil {
+			return quickActionDoneMsg{oldPath: o.Path, newPath: o.Path, err: err}
+		}
+		return quickActionDoneMsg{newPath: dp, notice: "Als neuer Entwurf dupliziert"}
+	}
+}
+
+func discardDraftCmd(o fileOption) tea.Cmd {
+	return func() tea.Msg {
+		err := discardDraft(o.Path)
+		return quickActionDoneMsg{oldPath: o.Path, notice: "Entwurf verworfen", err: err}
+	}
+}
+
+// applyTagSpec wendet "+tag -tag tag" auf eine Komma-Liste an; ohne
+// Vorzeichen wird hinzugefügt.
+func applyTagSpec(tags, spec string) string {
+	cur := splitCSV(tags)
+	has := func(t string) int {
+		for i, c := range cur {
+			if strings.EqualFold(c, t) {
+				return i
+			}
+		}
+		return -1
+	}
+	for _, f := range strings.FieldsFunc(spec, func(r rune) bool { return r == ' ' || r == ',' }) {
+		switch {
+		case strings.HasPrefix(f, "-"):
+			if i := has(f[1:]); i >= 0 {
+				cur = append(cur[:i], cur[i+1:]...)
+			}
+		default:
+			t := strings.TrimPrefix(f, "+")
+			if t != "" && has(t) < 0 {
+				cur = append(cur, t)
+			}
+		}
+	}
+	return trimJoin(cur)
+}
+
+func (m model) selectedOption() (fileOption, bool) {
+	if m.filter.Focused() || m.pickIdx <= 0 || m.pickIdx >= len(m.pickOptions) {
+		return fileOption{}, false
+	}
+	return m.pickOptions[m.pickIdx], true
+}
+
+// bulkKeys: Schnellaktionen, die auch auf alle markierten Einträge wirken.
+var bulkKeys = map[string]bool{"alt+s": true, "alt+t": true, "alt+n": true, "alt+b": true}
+
+// startQuick öffnet die Eingabe für eine Schnellaktion auf der Auswahl.
+func (m model) startQuick(key string) (model, tea.Cmd) {
+	o, ok := m.selectedOption()
+	bulk := len(m.selected) > 0 && bulkKeys[key]
+	if !ok && !bulk {
+		m.notice = "Erst mit TAB einen ADR oder Entwurf in der Liste wählen."
+		return m, nil
+	}
+	if o.Rev != "" && !bulk {
+		m.notice = "Historische Stände sind schreibgeschützt."
+		return m, nil
+	}
+	if li, held := m.lockedByOther(o); held && !bulk && key != "alt+d" {
+		m.notice = fmt.Sprintf("„%s“ wird gerade von %s bearbeitet.", o.Label, li.owner())
+		return m, nil
+	}
+	m.notice = ""
+	switch key {
+	case "alt+s":
+		m.quick = quickStatus
+		m.quickStatusIdx = 0
+		if ok {
+			m.quickStatusIdx = statusRank(m.searchDocs[o.Path].Status) % len(statuses)
+		}
+	case "alt+t":
+		m.quick = quickTags
+		return m, m.newQuickInput("Tags (+neu -alt): ")
+	case "alt+n":
+		m.quick = quickRenameTag
+		return m, m.newQuickInput("Tag umbenennen (alt=neu): ")
+	case "alt+b":
+		m.quick = quickRenameParticipant
+		return m, m.newQuickInput("Beteiligte umbenennen (alt=neu): ")
+	case "alt+d":
+		return m, duplicateCmd(m, o)
+	case "alt+a":
+		if o.Draft {
+			m.notice = "Entwürfe können nicht archiviert werden."
+			return m, nil
+		}
+		m.quick = quickArchive
+	case "alt+x":
+		if !o.Draft {
+			m.notice = "Nur Entwürfe können verworfen werden."
+			return m, nil
+		}
+		m.quick = quickDiscard
+	}
+	return m, nil
+}
+
+func (m *model) newQuickInput(prompt string) tea.Cmd {
+	ti := textinput.New()
+	ti.Prompt = prompt
+	ti.CharLimit = 256
+	ti.Width = 40
+	m.quickInput = ti
+	return m.quickInput.Focus()
+}
+
+// offerTakeover fragt, ob die fremde Sperre li auf o übernommen werden soll.
+// Das Ziel wird festgehalten, denn Filter und Auswahl können sich ändern.
+func (m *model) offerTakeover(o fileOption, li lockInfo) {
+	m.quick = quickTakeover
+	m.takeoverOpt, m.takeoverLock = o, li
+}
+
+func (m model) updateQuick(msg tea.KeyMsg) (model, tea.Cmd) {
+	o, ok := m.selectedOption()
+	if m.quick == quickTakeover {
+		o, ok = m.takeoverOpt, true
+	}
+	if msg.String() == "esc" || (!ok && len(m.selected) == 0) {
+		m.quick = quickNone
+		m.bulkPlan = nil
+		return m, nil
+	}
+	switch m.quick {
+	case quickStatus:
+		switch msg.String() {
+		case "left", "ctrl+p":
+			m.quickStatusIdx = (m.quickStatusIdx + len(statuses) - 1) % len(statuses)
+		case "right", "ctrl+n", "alt+s":
+			m.quickStatusIdx = (m.quickStatusIdx + 1) % len(statuses)
+		case "enter":
+			m.quick = quickNone
+			idx := m.quickStatusIdx
+			if len(m.selected) > 0 {
+				return m.planBulk(bulkOp{SetStatus: statuses[idx]})
+			}
+			return m, quickEditCmd(m, o, "Status: "+statuses[idx], func(e *model) { e.statusIdx = idx })
+		}
+		return m, nil
+	case quickTags, quickRenameTag, quickRenameParticipant:
+		if msg.String() == "enter" {
+			kind := m.quick
+			m.quick = quickNone
+			spec := m.quickInput.Value()
+			if kind == quickTags && len(m.selected) == 0 {
+				return m, quickEditCmd(m, o, "Tags aktualisiert", func(e *model) {
+					e.tags.SetValue(applyTagSpec(e.tags.Value(), spec))
+				})
+			}
+			op, err := quickBulkOp(kind, spec)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			return m.planBulk(op)
+		}
+		var cmd tea.Cmd
+		m.quickInput, cmd = m.quickInput.Update(msg)
+		return m, cmd
+	case quickBulkConfirm:
+		switch strings.ToLower(msg.String()) {
+		case "j", "y", "enter":
+			m.quick = quickNone
+			plan := m.bulkPlan
+			if len(plan) == 0 {
+				return m, nil
+			}
+			return m, bulkCmd(m, plan, m.bulkOp)
+		case "n":
+			m.quick = quickNone
+			m.bulkPlan = nil
+		}
+		return m, nil
+	case quickTakeover:
+		switch strings.ToLower(msg.String()) {
+		case "j", "y", "enter":
+			m.quick = quickNone
+			if err := m.takeOverLock(o); err != nil {
+				m.err = fmt.Errorf("Sperre konnte nicht übernommen werden: %w", err)
+				return m, nil
+			}
+			return m.openOption(o)
+		case "n":
+			m.quick = quickNone
+		}
+		return m, nil
+	case quickArchive, quickDiscard:
+		switch strings.ToLower(msg.String()) {
+		case "j", "y", "enter":
+			kind := m.quick
+			m.quick = quickNone
+			if kind == quickDiscard {
+				return m, discardDraftCmd(o)
+			}
+			return m, quickEditCmd(m, o, "Archiviert nach "+archiveDir+"/", func(e *model) {
+				e.targetDir = filepath.Join(filepath.Dir(o.Path), archiveDir)
+			})
+		case "n":
+			m.quick = quickNone
+		}
+	}
+	return m, nil
+}
+
+func (m model) viewQuick() string {
+	o, _ := m.selectedOption()
+	switch m.quick {
+	case quickStatus:
+		parts := make([]string, len(statuses))
+		for i, s := range statuses {
+			st := optionStyle
+			if i == m.quickStatusIdx {
+				st = selectedStyle
+			}
+			parts[i] = st.Render(s)
+		}
+		return labelStyle.Render("Status: ") + strings.Join(parts, "   ") +
+			"\n" + m.help("←/→ wählen · ENTER speichern · ESC abbrechen")
+	case quickTags, quickRenameTag, quickRenameParticipant:
+		target := ""
+		if n := len(m.selected); n > 0 {
+			target = fmt.Sprintf(" (%d markiert)", n)
+		}
+		return m.quickInput.View() + "\n" + m.help("ENTER Vorschau/speichern"+target+" · ESC abbrechen")
+	case quickBulkConfirm:
+		return labelStyle.Render("Vorschau: "+m.bulkOp.String()) + "\n" +
+			formatBulkPlan(m.bulkPlan, bulkPreviewMax) + "\n" + m.help("J/ENTER anwenden · N/ESC abbrechen")
+	case quickTakeover:
+		o, li := m.takeoverOpt, m.takeoverLock
+		return errorStyle.Render(fmt.Sprintf("„%s“ wird bearbeitet von %s (PID %d, seit %s, zuletzt aktiv %s).",
+			o.Label, li.owner(), li.PID, li.Started.Format("02.01. 15:04"), li.Heartbeat.Format("15:04:05"))) +
+			"\n" + m.help("J/ENTER Sperre übernehmen (die andere Sitzung pausiert ihr Autosave) · N/ESC abbrechen")
+	case quickArchive:
+		return errorStyle.Render(fmt.Sprintf("„%s“ nach %s/ verschieben?", o.Label, archiveDir)) +
+			"\n" + m.help("J/ENTER archivieren · N/ESC abbrechen")
+	case quickDiscard:
+		return errorStyle.Render(fmt.Sprintf("„%s“ endgültig verwerfen?", o.Label)) +
+			"\n" + m.help("J/ENTER verwerfen · N/ESC abbrechen")
+	}
+	return ""
+}
+
+// quickBulkOp übersetzt die Eingabe einer Schnellaktion in eine bulkOp.
+func quickBulkOp(kind quickKind, spec string) (bulkOp, error) {
+	switch kind {
+	case quickRenameTag:
+		ren, err := parseRenames(spec)
+		return bulkOp{RenameTags: ren}, err
+	case quickRenameParticipant:
+		ren, err := parseRenames(spec)
+		return bulkOp{RenameParticipants: ren}, err
+	}
+	var op bulkOp
+	for _, f := range strings.FieldsFunc(spec, func(r rune) bool { return r == ' ' || r == ',' }) {
+		if strings.HasPrefix(f, "-") {
+			op.RemoveTags = append(op.RemoveTags, f[1:])
+		} else if t := strings.TrimPrefix(f, "+"); t != "" {
+			op.AddTags = append(op.AddTags, t)
+		}
+	}
+	return op, nil
+}
+
+// planBulk berechnet die Vorschau für die markierten Einträge (oder, ohne
+// Markierung, für die aktuelle Auswahl).
+func (m model) planBulk(op bulkOp) (model, tea.Cmd) {
+	targets := m.bulkTargets()
+	if len(targets) == 0 {
+		if o, ok := m.selectedOption(); ok {
+			targets = []fileOption{o}
+		}
+	}
+	plan, err := planBulk(m, targets, op)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	m.bulkOp = op
+	m.bulkPlan = plan
+	m.quick = quickBulkConfirm
+	return m, nil
+}
+
+// handleQuickDone aktualisiert allOptions/searchDocs nur für die betroffenen
+// Pfade und behält Suche und Auswahl bei.
+func (m model) handleQuickDone(msg quickActionDoneMsg) model {
+	if msg.err != nil {
+		m.notice = ""
+		m.err = fmt.Errorf("Schnellaktion fehlgeschlagen: %w", msg.err)
+		return m
+	}
+	m.err = nil
+	m.notice = msg.notice
+	m.replaceOption(msg.oldPath, msg.newPath)
+	return m
+}
+
+// replaceOption ersetzt bzw. entfernt den Eintrag oldPath und fügt newPath
+// (neu eingelesen) an passender Stelle ein.
+func (m *model) replaceOption(oldPath, newPath string) {
+	m.updateOption(oldPath, newPath)
+	m.applyFilter(m.filter.Value())
+	m.selectPath(newPath)
+}
+
+// updateOption wie replaceOption, aber ohne Filter und Auswahl anzufassen.
+func (m *model) updateOption(oldPath, newPath string) {
+	pos := -1
+	if oldPath != "" {
+		for i, o := range m.allOptions {
+			if o.Path == oldPath {
+				pos = i
+				break
+			}
+		}
+		if pos >= 0 {
+			m.allOptions = append(m.allOptions[:pos], m.allOptions[pos+1:]...)
+		}
+		delete(m.searchDocs, oldPath)
+	}
+
+	if newPath != "" {
+		if _, err := os.Stat(newPath); errors.Is(err, os.ErrNotExist) {
+			newPath = "" // z. B. ins Archiv verschoben
+		}
+	}
+	if newPath != "" {
+		var opt fileOption
+		if strings.HasSuffix(newPath, ".draft.json") {
+			opt = draftOption(newPath)
+		} else {
+			opt = adrOption(newPath)
+		}
+		if pos < 0 {
+			pos = insertPos(m.allOptions, opt)
+		}
+		m.allOptions = append(m.allOptions[:pos], append([]fileOption{opt}, m.allOptions[pos:]...)...)
+		for k, d := range buildSearchDocs([]fileOption{opt}) {
+			m.searchDocs[k] = d
+		}
+	}
+}
+
+// insertPos: Entwürfe direkt unter "Neuer ADR", ADRs nach Nummer.
+func insertPos(all []fileOption, opt fileOption) int {
+	if opt.Draft {
+		return min(1, len(all))
+	}
+	for i, o := range all {
+		if o.Path == newAdrSentinel || o.Draft || o.Rev != "" {
+			continue
+		}
+		if lessADR(opt, o) {
+			return i
+		}
+	}
+	for i, o := range all {
+		if o.Rev != "" {
+			return i
+		}
+	}
+	return len(all)
+}
+
+// selectPath wählt path in der gefilterten Liste aus; false, wenn er dort
+// nicht steht.
+func (m *model) selectPath(path string) bool {
+	found := false
+	for i, o := range m.pickOptions {
+		if o.Path == path {
+			m.pickIdx, found = i, true
+			break
+		}
+	}
+	if m.pickIdx >= len(m.pickOptions) {
+		m.pickIdx = 0
+	}
+	m.scrollPick()
+	return found
+}
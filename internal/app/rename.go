@@ -0,0 +1,568 @@
+package app
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/* ------- Umnummerieren und Umbenennen samt Verweisen in anderen Dateien ---- */
+
+// renameOp: eine ADR-Datei bekommt eine neue Nummer und/oder einen neuen
+// Titel und damit einen neuen Dateinamen.
+type renameOp struct {
+	Old, New           string
+	OldNo, NewNo       int
+	OldTitle, NewTitle string
+	NS                 string
+}
+
+// fileEdit ist eine geänderte Datei; bei umbenannten ADRs ist Path der
+// alte Pfad.
+type fileEdit struct {
+	Path     string
+	Old, New string
+	Refs     int // angepasste Verweise
+}
+
+type renamePlan struct {
+	Ops   []renameOp
+	Edits []fileEdit
+	// Ambiguous nennt Dateien mit Erwähnungen einer Nummer, die nach einer
+	// Doppelung weiter vergeben ist – dort bleibt die Erwähnung stehen.
+	Ambiguous []string
+}
+
+var mdLinkRe = regexp.MustCompile(`\]\(([^)\s#]+)(#[^)\s]*)?\)`)
+
+// refFiles sind alle Markdown-Dateien, die auf ADRs verweisen können: die
+// ADR-Verzeichnisse samt Archiv und die README der Wurzel.
+func refFiles() []string {
+	var out []string
+	seen := map[string]bool{}
+	add := func(p string) {
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	for _, r := range adrRoots {
+		for _, dir := range []string{r.Dir, filepath.Join(r.Dir, archiveDir)} {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				continue
+			}
+			for _, e := range entries {
+				if !e.IsDir() && strings.HasSuffix(e.Name(), ".md") {
+					add(filepath.Join(dir, e.Name()))
+				}
+			}
+		}
+	}
+	if _, err := os.Stat("README.md"); err == nil {
+		add("README.md")
+	}
+	sort.Strings(out)
+	return out
+}
+
+// rewriteLinks passt Markdown-Links in content (Datei path) an, deren Ziel
+// eine umbenannte Datei ist.
+func rewriteLinks(path, content string, ops []renameOp) (string, int) {
+	moved := map[string]string{}
+	for _, op := range ops {
+		moved[filepath.Clean(op.Old)] = op.New
+	}
+	dir := filepath.Dir(path)
+	n := 0
+	out := mdLinkRe.ReplaceAllStringFunc(content, func(link string) string {
+		mm := mdLinkRe.FindStringSubmatch(link)
+		target := mm[1]
+		if strings.Contains(target, "://") {
+			return link
+		}
+		from := filepath.Clean(filepath.Join(dir, filepath.FromSlash(target)))
+		to, ok := moved[from]
+		if !ok {
+			return link
+		}
+		n++
+		rel := filepath.Join(filepath.Dir(filepath.FromSlash(target)), filepath.Base(to))
+		if filepath.Dir(from) != filepath.Dir(filepath.Clean(to)) { // z. B. ins Archiv verschoben
+			if r, err := filepath.Rel(dir, to); err == nil {
+				rel = r
+			}
+		}
+		return "](" + filepath.ToSlash(rel) + mm[2] + ")"
+	})
+	return out, n
+}
+
+// mentionRe findet Erwähnungen wie "ADR 0007", "ADR-7" oder mit Namensraum
+// "billing/ADR-0007". Ohne Namensraum darf kein "/" davor stehen.
+func mentionRe(ns string, no int) *regexp.Regexp {
+	prefix := `(^|[^/\w])`
+	if ns != "" {
+		prefix = `(` + `\b` + regexp.QuoteMeta(ns) + `/)`
+	}
+	return regexp.MustCompile(prefix + `(ADR[- ])0*` + strconv.Itoa(no) + `\b`)
+}
+
+// rewriteMentions ersetzt Erwähnungen der alten Nummer. Dateien im selben
+// ADR-Verzeichnis nennen den ADR ohne Namensraum, alle anderen mit.
+func rewriteMentions(path, content string, op renameOp) (string, int) {
+	own, ok := rootFor(path)
+	if !ok && filepath.Base(filepath.Dir(path)) == archiveDir {
+		own, ok = rootFor(filepath.Dir(path))
+	}
+	if !ok {
+		own = adrRoots[0] // z. B. README.md der Wurzel
+	}
+	ns := op.NS
+	if own.Dir == filepath.Dir(op.Old) || (ns == "" && !ok) {
+		ns = ""
+	} else if ns == "" {
+		return content, 0 // aus einem anderen Namensraum ist das Haupt-Verzeichnis nicht adressierbar
+	}
+	re := mentionRe(ns, op.OldNo)
+	n := 0
+	out := re.ReplaceAllStringFunc(content, func(s string) string {
+		mm := re.FindStringSubmatch(s)
+		n++
+		return mm[1] + mm[2] + fmt.Sprintf("%04d", op.NewNo)
+	})
+	return out, n
+}
+
+// retitleVerweise passt in "## Verweise" den Titel hinter Verweisen auf den
+// umbenannten ADR an.
+func retitleVerweise(content string, op renameOp) string {
+	if op.OldTitle == "" || op.OldTitle == op.NewTitle {
+		return content
+	}
+	loc := regexp.MustCompile(`(?m)^##\s+Verweise\s*$`).FindStringIndex(content)
+	if loc == nil {
+		return content
+	}
+	end := len(content)
+	if next := regexp.MustCompile(`(?m)^##\s`).FindStringIndex(content[loc[1]:]); next != nil {
+		end = loc[1] + next[0]
+	}
+	base := filepath.Base(op.New)
+	mention := mentionRe(op.NS, op.NewNo)
+	lines := strings.Split(content[loc[1]:end], "\n")
+	for i, l := range lines {
+		if strings.Contains(l, base) || mention.MatchString(l) || mentionRe("", op.NewNo).MatchString(l) {
+			lines[i] = strings.ReplaceAll(l, op.OldTitle, op.NewTitle)
+		}
+	}
+	return content[:loc[1]] + strings.Join(lines, "\n") + content[end:]
+}
+
+// retitleHeading setzt Nummer und Titel in der Überschrift "# ADR 0042: …".
+func retitleHeading(content string, op renameOp) string {
+	re := regexp.MustCompile(`(?m)^(#\s*ADR\s+)0*` + strconv.Itoa(op.OldNo) + `:(.*)$`)
+	return re.ReplaceAllStringFunc(content, func(s string) string {
+		mm := re.FindStringSubmatch(s)
+		title := mm[2]
+		if op.NewTitle != "" && op.NewTitle != op.OldTitle {
+			title = " " + op.NewTitle
+		}
+		return mm[1] + fmt.Sprintf("%04d", op.NewNo) + ":" + title
+	})
+}
+
+// renamedPath: neue Nummer, mit title auch ein neuer Titel-Teil.
+func renamedPath(path string, newNo int, title string) string {
+	base := filepath.Base(path)
+	if mm := adrFileRe.FindStringSubmatchIndex(base); mm != nil {
+		rest := base[mm[3]:]
+		if title != "" {
+			rest = "-" + slugify(title) + ".md"
+		}
+		base = base[:mm[2]] + fmt.Sprintf("%04d", newNo) + rest
+	}
+	return filepath.Join(filepath.Dir(path), base)
+}
+
+// numberFreed: bleibt nach den Umbenennungen keine andere Datei mit der
+// alten Nummer im Verzeichnis, sind Erwähnungen eindeutig.
+func numberFreed(op renameOp, ops []renameOp) bool {
+	leaving := map[string]bool{}
+	for _, o := range ops {
+		leaving[filepath.Clean(o.Old)] = true
+	}
+	for _, o := range scanADRFiles(filepath.Dir(op.Old)) {
+		if o.No == op.OldNo && !leaving[filepath.Clean(o.Path)] {
+			return false
+		}
+	}
+	return true
+}
+
+// planRenames liest alle betroffenen Dateien und berechnet die neuen Inhalte,
+// ohne etwas zu schreiben.
+func planRenames(ops []renameOp) (renamePlan, error) {
+	plan := renamePlan{Ops: ops}
+	renamed := map[string]renameOp{}
+	for _, op := range ops {
+		if _, err := os.Stat(op.New); err == nil {
+			return plan, fmt.Errorf("%s existiert bereits", op.New)
+		}
+		renamed[filepath.Clean(op.Old)] = op
+	}
+	freed := map[int]bool{}
+	for i, op := range ops {
+		freed[i] = op.OldNo != op.NewNo && numberFreed(op, ops)
+	}
+	for _, p := range refFiles() {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return plan, err
+		}
+		txt, n := rewriteLinks(p, string(b), ops)
+		for i, op := range ops {
+			if op.OldNo == op.NewNo {
+				txt = retitleVerweise(txt, op)
+				continue
+			}
+			if filepath.Clean(p) == filepath.Clean(op.Old) {
+				continue // die Überschrift übernimmt retitleHeading
+			}
+			if !freed[i] {
+				if no, _ := adrNumber(filepath.Base(p)); no == op.OldNo && filepath.Dir(p) == filepath.Dir(op.Old) {
+					continue // die Datei, die die Nummer behält, meint sich selbst
+				}
+				if _, k := rewriteMentions(p, txt, op); k > 0 {
+					plan.Ambiguous = append(plan.Ambiguous, p)
+				}
+				continue
+			}
+			var k int
+			txt, k = rewriteMentions(p, txt, op)
+			n += k
+			txt = retitleVerweise(txt, op)
+		}
+		op, self := renamed[filepath.Clean(p)]
+		if self {
+			txt = retitleHeading(txt, op)
+		}
+		if txt != string(b) || self {
+			plan.Edits = append(plan.Edits, fileEdit{Path: p, Old: string(b), New: txt, Refs: n})
+		}
+	}
+	return plan, nil
+}
+
+// String fasst den Plan zusammen; mit diff folgt je Datei der Unterschied.
+func (p renamePlan) String(diff bool) string {
+	var b strings.Builder
+	for _, op := range p.Ops {
+		id := adrID(op.NS, op.OldNo)
+		if op.NewNo != op.OldNo {
+			id += " → " + adrID(op.NS, op.NewNo)
+		}
+		fmt.Fprintf(&b, "%s\n  %s → %s\n", id, op.Old, op.New)
+	}
+	for _, e := range p.Edits {
+		if e.Refs > 0 {
+			fmt.Fprintf(&b, "  Verweise angepasst: %s (%d)\n", e.Path, e.Refs)
+		}
+	}
+	for _, a := range p.Ambiguous {
+		fmt.Fprintf(&b, "  %s %s nennt eine doppelt vergebene Nummer – bitte prüfen\n", errorStyle.Render("⚠"), a)
+	}
+	if diff {
+		for _, e := range p.Edits {
+			if ld := lineDiff(splitLines(e.Old), splitLines(e.New)); diffChanged(ld) {
+				fmt.Fprintf(&b, "\n%s\n%s\n", labelStyle.Render(e.Path), renderDiff(ld, 2))
+			}
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// refEdits: nur die Änderungen an anderen Dateien (ohne die ADRs der Ops).
+func (p renamePlan) refEdits() []fileEdit {
+	self := map[string]bool{}
+	for _, op := range p.Ops {
+		self[filepath.Clean(op.Old)] = true
+	}
+	var out []fileEdit
+	for _, e := range p.Edits {
+		if !self[filepath.Clean(e.Path)] {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// writeEdits sichert und schreibt geänderte Dateien.
+func writeEdits(edits []fileEdit) error {
+	for _, e := range edits {
+		if err := backupFile(e.Path); err != nil {
+			return fmt.Errorf("Sicherung fehlgeschlagen: %w", err)
+		}
+		if err := atomicWrite(e.Path, []byte(e.New), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyRenamePlan sichert alle betroffenen Dateien, verschiebt die ADRs
+// (mit git mv, wenn sie versioniert sind) und schreibt die neuen Inhalte.
+func applyRenamePlan(p renamePlan) error {
+	for _, e := range p.Edits {
+		if err := backupFile(e.Path); err != nil {
+			return fmt.Errorf("Sicherung fehlgeschlagen: %w", err)
+		}
+	}
+	moved := map[string]string{}
+	for _, op := range p.Ops {
+		if err := gitMove(op.Old, op.New); err != nil {
+			return err
+		}
+		moved[filepath.Clean(op.Old)] = op.New
+	}
+	for _, e := range p.Edits {
+		path := e.Path
+		if to, ok := moved[filepath.Clean(path)]; ok {
+			path = to
+		}
+		if err := atomicWrite(path, []byte(e.New), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gitMove benennt mit git mv um, wenn die Datei versioniert ist, sonst
+// direkt.
+func gitMove(from, to string) error {
+	if _, err := os.Stat(to); err == nil {
+		return fmt.Errorf("%s existiert bereits", to)
+	}
+	if exec.Command("git", "ls-files", "--error-unmatch", "--", from).Run() == nil {
+		if out, err := exec.Command("git", "mv", "--", from, to).CombinedOutput(); err != nil {
+			return fmt.Errorf("git mv: %s", strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+	return os.Rename(from, to)
+}
+
+/* --------------------------- Kollisionen finden --------------------------- */
+
+// collisions gruppiert ADRs mit gleicher Nummer im selben Verzeichnis; der
+// älteste Eintrag jeder Gruppe steht vorn und behält seine Nummer.
+func collisions(opts []fileOption) [][]fileOption {
+	groups := map[string][]fileOption{}
+	var keys []string
+	for _, o := range opts {
+		if o.No <= 0 {
+			continue
+		}
+		k := filepath.Dir(o.Path) + "\x00" + strconv.Itoa(o.No)
+		if groups[k] == nil {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], o)
+	}
+	sort.Strings(keys)
+	var out [][]fileOption
+	for _, k := range keys {
+		g := groups[k]
+		if len(g) < 2 {
+			continue
+		}
+		age := map[string]string{}
+		for _, o := range g {
+			age[o.Path] = addedAt(o.Path)
+		}
+		sort.SliceStable(g, func(i, j int) bool {
+			if age[g[i].Path] != age[g[j].Path] {
+				return age[g[i].Path] < age[g[j].Path]
+			}
+			return g[i].Path < g[j].Path
+		})
+		out = append(out, g)
+	}
+	return out
+}
+
+// addedAt: Zeitpunkt des ersten Commits (sortierbar), sonst das
+// Erstelldatum aus der Datei; nicht versionierte Dateien gelten als neuer.
+func addedAt(path string) string {
+	out, err := exec.Command("git", "log", "--diff-filter=A", "--follow", "--format=%cI", "--", path).Output()
+	if lines := strings.Fields(string(out)); err == nil && len(lines) > 0 {
+		return lines[len(lines)-1]
+	}
+	if d := parseADRForSearch(path).CreatedDate; d != "" {
+		return "~" + d // nach allen Commit-Zeitpunkten
+	}
+	return "~~"
+}
+
+/* ------------------- adronaut renumber / adronaut rename ------------------ */
+
+// numberPool vergibt je Verzeichnis fortlaufend freie Nummern, auch für
+// mehrere Umbenennungen in einem Lauf.
+type numberPool map[string]map[int]bool
+
+func (p numberPool) next(dir string) (int, error) {
+	if p[dir] == nil {
+		used, err := usedNumbers(dir)
+		if err != nil {
+			return 0, err
+		}
+		p[dir] = used
+	}
+	n := nextFree(p[dir])
+	p[dir][n] = true
+	return n, nil
+}
+
+// singleOp baut die Umbenennung einer Datei: neue Nummer (0 = behalten, -1 =
+// nächste freie) und/oder neuer Titel.
+func singleOp(arg string, no int, title string) (renameOp, error) {
+	path := userPath(arg)
+	o := adrOption(path)
+	if _, err := os.Stat(path); err != nil || o.No == 0 {
+		return renameOp{}, fmt.Errorf("%s ist keine ADR-Datei", arg)
+	}
+	op := renameOp{Old: path, OldNo: o.No, NewNo: o.No, NS: o.NS}
+	op.OldTitle = parseADRForSearch(path).Title
+	op.NewTitle = op.OldTitle
+	if t := strings.TrimSpace(title); t != "" {
+		op.NewTitle = t
+	}
+	switch {
+	case no < 0:
+		n, err := numberPool{}.next(filepath.Dir(path))
+		if err != nil {
+			return op, err
+		}
+		op.NewNo = n
+	case no > 0 && no != o.No:
+		used, err := usedNumbers(filepath.Dir(path))
+		if err != nil {
+			return op, err
+		}
+		if used[no] {
+			return op, fmt.Errorf("%s ist schon vergeben", adrID(o.NS, no))
+		}
+		op.NewNo = no
+	}
+	newTitle := ""
+	if op.NewTitle != op.OldTitle {
+		newTitle = op.NewTitle
+	}
+	op.New = renamedPath(path, op.NewNo, newTitle)
+	if op.New == op.Old {
+		return op, errors.New("nichts zu tun: Nummer und Titel unverändert")
+	}
+	return op, nil
+}
+
+// previewOrApply zeigt den Plan als Diff und wendet ihn mit yes an.
+func previewOrApply(ops []renameOp, yes bool) error {
+	plan, err := planRenames(ops)
+	if err != nil {
+		return err
+	}
+	if !yes {
+		fmt.Println(labelStyle.Render("Vorschau"))
+	}
+	fmt.Println(plan.String(!yes))
+	if !yes {
+		fmt.Println(helpStyle.Render("Mit --yes anwenden."))
+		return nil
+	}
+	if err := applyRenamePlan(plan); err != nil {
+		return err
+	}
+	fmt.Println(okStyle.Render(fmt.Sprintf("✔ %d ADR(s) umbenannt, %d weitere Datei(en) angepasst", len(ops), len(plan.refEdits()))))
+	return nil
+}
+
+func runRenumber(args []string) error {
+	fs := flag.NewFlagSet("renumber", flag.ContinueOnError)
+	to := fs.Int("to", 0, "neue Nummer (nur mit einer Datei; Standard: nächste freie)")
+	yes := fs.Bool("yes", false, "Änderungen ohne Rückfrage schreiben")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Benutzung: adronaut renumber [--yes]                  doppelte Nummern auflösen")
+		fmt.Fprintln(fs.Output(), "           adronaut renumber <datei> [--to n] [--yes] einen ADR umnummerieren")
+		fs.PrintDefaults()
+	}
+	files, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) > 1 || (*to != 0 && len(files) == 0) {
+		fs.Usage()
+		return errors.New("renumber: --to nur mit genau einer Datei")
+	}
+	if len(files) == 1 {
+		no := *to
+		if no == 0 {
+			no = -1
+		}
+		op, err := singleOp(files[0], no, "")
+		if err != nil {
+			return err
+		}
+		return previewOrApply([]renameOp{op}, *yes)
+	}
+
+	var ops []renameOp
+	pool := numberPool{}
+	for _, g := range collisions(scanAllADRFiles()) {
+		fmt.Printf("%s doppelt vergeben, %s behält die Nummer\n", adrID(g[0].NS, g[0].No), g[0].Path)
+		for _, o := range g[1:] {
+			n, err := pool.next(filepath.Dir(o.Path))
+			if err != nil {
+				return err
+			}
+			title := parseADRForSearch(o.Path).Title
+			ops = append(ops, renameOp{Old: o.Path, New: renamedPath(o.Path, n, ""), OldNo: o.No, NewNo: n,
+				OldTitle: title, NewTitle: title, NS: o.NS})
+		}
+	}
+	if len(ops) == 0 {
+		fmt.Println("Keine doppelten Nummern gefunden.")
+		return nil
+	}
+	return previewOrApply(ops, *yes)
+}
+
+func runRename(args []string) error {
+	fs := flag.NewFlagSet("rename", flag.ContinueOnError)
+	title := fs.String("title", "", "neuer Titel (Dateiname und Überschrift)")
+	to := fs.Int("to", 0, "neue Nummer")
+	yes := fs.Bool("yes", false, "Änderungen ohne Rückfrage schreiben")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Benutzung: adronaut rename <datei> [--title t] [--to n] [--yes]")
+		fs.PrintDefaults()
+	}
+	files, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 || (*title == "" && *to == 0) {
+		fs.Usage()
+		return errors.New("rename: genau eine Datei und --title und/oder --to angeben")
+	}
+	op, err := singleOp(files[0], *to, *title)
+	if err != nil {
+		return err
+	}
+	return previewOrApply([]renameOp{op}, *yes)
+}
@@ -0,0 +1,168 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMentionRe(t *testing.T) {
+	tests := []struct {
+		ns   string
+		no   int
+		text string
+		want bool
+	}{
+		{"", 7, "siehe ADR 0007.", true},
+		{"", 7, "siehe ADR-7", true},
+		{"", 7, "ADR-00007 am Zeilenanfang", true},
+		{"", 7, "(ADR-0007)", true},
+		{"", 7, "ADR-0070", false},
+		{"", 7, "ADR-17", false},
+		{"", 7, "ADR-0007a", false},
+		{"", 7, "XADR-0007", false},
+		{"", 7, "billing/ADR-0007", false},
+		{"billing", 7, "billing/ADR-0007", true},
+		{"billing", 7, "siehe billing/ADR 07", true},
+		{"billing", 7, "ADR-0007", false},
+		{"billing", 7, "auth/ADR-0007", false},
+		{"billing", 7, "xbilling/ADR-0007", false},
+		{"billing", 7, "billing/ADR-0071", false},
+	}
+	for _, tt := range tests {
+		if got := mentionRe(tt.ns, tt.no).MatchString(tt.text); got != tt.want {
+			t.Errorf("mentionRe(%q, %d) on %q = %v, want %v", tt.ns, tt.no, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRewriteLinks(t *testing.T) {
+	adr := filepath.Join("docs", "adr")
+	arch := filepath.Join(adr, archiveDir)
+	tests := []struct {
+		name, path, in, want string
+		op                   renameOp
+	}{
+		{
+			name: "umnummeriert",
+			path: filepath.Join(adr, "ADR-0001-a.md"),
+			in:   "[B](ADR-0002-b.md#kontext)",
+			want: "[B](ADR-0003-b.md#kontext)",
+			op:   renameOp{Old: filepath.Join(adr, "ADR-0002-b.md"), New: filepath.Join(adr, "ADR-0003-b.md")},
+		},
+		{
+			name: "ins Archiv",
+			path: filepath.Join(adr, "ADR-0001-a.md"),
+			in:   "[B](ADR-0002-b.md) [B](./ADR-0002-b.md)",
+			want: "[B](archiv/ADR-0002-b.md) [B](archiv/ADR-0002-b.md)",
+			op:   renameOp{Old: filepath.Join(adr, "ADR-0002-b.md"), New: filepath.Join(arch, "ADR-0002-b.md")},
+		},
+		{
+			name: "aus dem Archiv",
+			path: filepath.Join(adr, "ADR-0001-a.md"),
+			in:   "[B](archiv/ADR-0002-b.md)",
+			want: "[B](ADR-0002-b.md)",
+			op:   renameOp{Old: filepath.Join(arch, "ADR-0002-b.md"), New: filepath.Join(adr, "ADR-0002-b.md")},
+		},
+		{
+			name: "vom Archiv aus",
+			path: filepath.Join(arch, "ADR-0004-d.md"),
+			in:   "[B](../ADR-0002-b.md)",
+			want: "[B](../ADR-0005-b.md)",
+			op:   renameOp{Old: filepath.Join(adr, "ADR-0002-b.md"), New: filepath.Join(adr, "ADR-0005-b.md")},
+		},
+		{
+			name: "Nachbar im Archiv",
+			path: filepath.Join(arch, "ADR-0004-d.md"),
+			in:   "[B](../ADR-0002-b.md)",
+			want: "[B](ADR-0002-b.md)",
+			op:   renameOp{Old: filepath.Join(adr, "ADR-0002-b.md"), New: filepath.Join(arch, "ADR-0002-b.md")},
+		},
+		{
+			name: "fremde Links bleiben",
+			path: filepath.Join(adr, "ADR-0001-a.md"),
+			in:   "[X](https://example.org/ADR-0002-b.md) [C](ADR-0003-c.md)",
+			want: "[X](https://example.org/ADR-0002-b.md) [C](ADR-0003-c.md)",
+			op:   renameOp{Old: filepath.Join(adr, "ADR-0002-b.md"), New: filepath.Join(adr, "ADR-0009-b.md")},
+		},
+	}
+	for _, tt := range tests {
+		got, _ := rewriteLinks(tt.path, tt.in, []renameOp{tt.op})
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Nach einer Doppelung bleibt die Nummer an der anderen Datei; Erwähnungen
+// sind dann mehrdeutig und werden nur gemeldet, Links aber angepasst.
+func TestPlanRenamesAmbiguousCollision(t *testing.T) {
+	t.Chdir(t.TempDir())
+	dir := filepath.Join("docs", "adr")
+	withRoots(t, adrRoot{NS: "", Dir: dir})
+	files := map[string]string{
+		"ADR-0002-a.md":        "# ADR 0002: A\n",
+		"ADR-0002-b.md":        "# ADR 0002: B\n",
+		"ADR-0001-c.md":        "# ADR 0001: C\n\nWie in ADR-0002 beschrieben, siehe [B](ADR-0002-b.md).\n",
+		"archiv/ADR-0004-d.md": "# ADR 0004: D\n\nErsetzt durch ADR-0002 ([B](../ADR-0002-b.md)).\n",
+	}
+	if err := os.MkdirAll(filepath.Join(dir, archiveDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for n, c := range files {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(n)), []byte(c), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	op := renameOp{
+		Old: filepath.Join(dir, "ADR-0002-b.md"), New: filepath.Join(dir, "ADR-0003-b.md"),
+		OldNo: 2, NewNo: 3, OldTitle: "B", NewTitle: "B",
+	}
+	plan, err := planRenames([]renameOp{op})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := filepath.Join(dir, "ADR-0001-c.md")
+	d := filepath.Join(dir, archiveDir, "ADR-0004-d.md")
+	if len(plan.Ambiguous) != 2 || plan.Ambiguous[0] != c || plan.Ambiguous[1] != d {
+		t.Errorf("Ambiguous = %v, want [%s %s]", plan.Ambiguous, c, d)
+	}
+	edits := map[string]string{}
+	for _, e := range plan.Edits {
+		edits[e.Path] = e.New
+	}
+	if want := "# ADR 0001: C\n\nWie in ADR-0002 beschrieben, siehe [B](ADR-0003-b.md).\n"; edits[c] != want {
+		t.Errorf("%s:\n%s\nwant:\n%s", c, edits[c], want)
+	}
+	if want := "# ADR 0004: D\n\nErsetzt durch ADR-0002 ([B](../ADR-0003-b.md)).\n"; edits[d] != want {
+		t.Errorf("%s:\n%s\nwant:\n%s", d, edits[d], want)
+	}
+	if want := "# ADR 0003: B\n"; edits[op.Old] != want {
+		t.Errorf("%s = %q, want %q", op.Old, edits[op.Old], want)
+	}
+	if _, ok := edits[filepath.Join(dir, "ADR-0002-a.md")]; ok {
+		t.Error("ADR-0002-a.md must stay untouched")
+	}
+
+	// Ohne die zweite Datei ist die Nummer frei und die Erwähnung eindeutig.
+	if err := os.Remove(filepath.Join(dir, "ADR-0002-a.md")); err != nil {
+		t.Fatal(err)
+	}
+	plan, err = planRenames([]renameOp{op})
+	if err != nil {
+		t.Fatal(err)
+	}
+	edits = map[string]string{}
+	for _, e := range plan.Edits {
+		edits[e.Path] = e.New
+	}
+	if want := "# ADR 0001: C\n\nWie in ADR-0003 beschrieben, siehe [B](ADR-0003-b.md).\n"; edits[c] != want {
+		t.Errorf("freed number: %s =\n%s", c, edits[c])
+	}
+	if want := "# ADR 0004: D\n\nErsetzt durch ADR-0003 ([B](../ADR-0003-b.md)).\n"; edits[d] != want {
+		t.Errorf("freed number: %s =\n%s", d, edits[d])
+	}
+	if len(plan.Ambiguous) != 0 {
+		t.Errorf("freed number: Ambiguous = %v", plan.Ambiguous)
+	}
+}
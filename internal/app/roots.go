@@ -0,0 +1,250 @@
+package app
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+/* ----------------- Monorepo: mehrere ADR-Verzeichnisse -------------------- */
+
+// adrRoot ist ein ADR-Verzeichnis mit eigener Nummernfolge. Der Namensraum
+// ist für das Haupt-Verzeichnis leer, sonst z. B. "billing" für
+// services/billing/docs/adr.
+type adrRoot struct {
+	NS  string
+	Dir string // relativ zur Repo-Wurzel
+}
+
+// adrRoots wird von initWorkspace befüllt; der erste Eintrag ist immer das
+// Haupt-Verzeichnis (adrDir).
+var adrRoots = []adrRoot{{Dir: "."}}
+
+// skipDirs werden bei der Suche nach ADR-Verzeichnissen nicht betreten,
+// ebenso archiv/ (gehört zu seinem ADR-Verzeichnis, ist kein Namensraum).
+var skipDirs = map[string]bool{"node_modules": true, "vendor": true, "testdata": true}
+
+func multiRoot() bool { return len(adrRoots) > 1 }
+
+// discoverADRRoots sammelt alle Verzeichnisse unterhalb von base, die ADRs
+// enthalten; die Pfade sind relativ zu base. Mit configured (Namensraum →
+// Verzeichnis) wird nicht gesucht.
+func discoverADRRoots(base, home string, configured map[string]string) []adrRoot {
+	roots := []adrRoot{{Dir: filepath.Clean(home)}}
+	seen := map[string]bool{roots[0].Dir: true}
+	if len(configured) > 0 {
+		names := make([]string, 0, len(configured))
+		for ns := range configured {
+			names = append(names, ns)
+		}
+		sort.Strings(names)
+		for _, ns := range names {
+			dir := filepath.Clean(filepath.FromSlash(configured[ns]))
+			if ns == "" || seen[dir] {
+				continue
+			}
+			seen[dir] = true
+			roots = append(roots, adrRoot{NS: ns, Dir: dir})
+		}
+		return roots
+	}
+
+	var dirs []string
+	_ = filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if p, err = filepath.Rel(base, p); err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if p != "." && (strings.HasPrefix(d.Name(), ".") || skipDirs[d.Name()] || d.Name() == archiveDir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if dir := filepath.Dir(p); adrFileRe.MatchString(d.Name()) && !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+		return nil
+	})
+	sort.Strings(dirs)
+
+	taken := map[string]bool{"": true}
+	for _, dir := range dirs {
+		ns := namespaceFor(dir, false)
+		if taken[ns] {
+			ns = namespaceFor(dir, true)
+		}
+		taken[ns] = true
+		roots = append(roots, adrRoot{NS: ns, Dir: dir})
+	}
+	return roots
+}
+
+// namespaceFor leitet den Namensraum aus dem Pfad ab: bekannte Endungen wie
+// docs/adr fallen weg, übrig bleibt der letzte (mit full der ganze) Rest.
+func namespaceFor(dir string, full bool) string {
+	rest := ownerDir(dir)
+	if rest == "." {
+		rest = filepath.ToSlash(dir)
+	}
+	if full {
+		return rest
+	}
+	return rest[strings.LastIndex(rest, "/")+1:]
+}
+
+// ownerDir ist der Teilbaum, zu dem ein ADR-Verzeichnis gehört:
+// services/billing/docs/adr → services/billing.
+func ownerDir(dir string) string {
+	d := filepath.ToSlash(filepath.Clean(dir))
+	suffix := ""
+	for _, c := range adrDirCandidates {
+		if (d == c || strings.HasSuffix(d, "/"+c)) && len(c) > len(suffix) {
+			suffix = c
+		}
+	}
+	switch {
+	case suffix == "":
+		return d
+	case d == suffix:
+		return "."
+	}
+	return strings.TrimSuffix(d, "/"+suffix)
+}
+
+// rootFor liefert das ADR-Verzeichnis, in dem path liegt.
+func rootFor(path string) (adrRoot, bool) {
+	dir := filepath.Clean(filepath.Dir(path))
+	for _, r := range adrRoots {
+		if r.Dir == dir {
+			return r, true
+		}
+	}
+	return adrRoot{Dir: dir}, false
+}
+
+func isADRRoot(dir string) bool {
+	_, ok := rootFor(filepath.Join(dir, "x"))
+	return ok
+}
+
+// adrID ist die Kennung, unter der ein ADR genannt wird, z. B.
+// "billing/ADR-0003".
+func adrID(ns string, no int) string {
+	id := fmt.Sprintf("ADR-%04d", no)
+	if ns != "" {
+		id = ns + "/" + id
+	}
+	return id
+}
+
+// number ist die Anzeige in der Spalte "Nr.", mit Namensraum.
+func (o fileOption) number() string {
+	if o.No <= 0 {
+		return ""
+	}
+	if o.NS != "" {
+		return fmt.Sprintf("%s/%04d", o.NS, o.No)
+	}
+	return fmt.Sprintf("%04d", o.No)
+}
+
+// lessADR: Haupt-Verzeichnis zuerst, dann nach Namensraum, darin nach Nummer.
+func lessADR(a, b fileOption) bool {
+	if a.NS != b.NS {
+		return a.NS < b.NS
+	}
+	return a.No < b.No
+}
+
+// repoADRRoots bestimmt die ADR-Verzeichnisse eines anderen Repositorys
+// (für den Katalog), ohne das Arbeitsverzeichnis zu wechseln.
+func repoADRRoots(root string) ([]adrRoot, error) {
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return nil, err
+	}
+	home := resolveADRDir(root, cfg)
+	if home == "" {
+		home = "."
+	}
+	return discoverADRRoots(root, home, cfg.Roots), nil
+}
+
+// scanAllADRFiles liest alle ADR-Verzeichnisse.
+func scanAllADRFiles() []fileOption {
+	return scanRoots(".", adrRoots)
+}
+
+// scanRoots liest die ADR-Verzeichnisse roots unterhalb von base.
+func scanRoots(base string, roots []adrRoot) []fileOption {
+	var out []fileOption
+	for _, r := range roots {
+		out = append(out, scanADRFilesNS(filepath.Join(base, r.Dir), r.NS)...)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].NS < out[j].NS })
+	return out
+}
+
+// defaultNewRoot: das ADR-Verzeichnis des Teilbaums, aus dem ADRonaut
+// gestartet wurde, sonst das Haupt-Verzeichnis.
+func defaultNewRoot() adrRoot { return ownerRoot(adrRoots, userPath(".")) }
+
+// ownerRoot: das ADR-Verzeichnis des Teilbaums, in dem path liegt
+// (services/billing/… → billing), sonst das erste aus roots.
+func ownerRoot(roots []adrRoot, path string) adrRoot {
+	here := filepath.ToSlash(path)
+	best, bestLen := roots[0], -1
+	for _, r := range roots[1:] {
+		owner := ownerDir(r.Dir)
+		if owner == "." {
+			continue
+		}
+		if (here == owner || strings.HasPrefix(here, owner+"/")) && len(owner) > bestLen {
+			best, bestLen = r, len(owner)
+		}
+	}
+	return best
+}
+
+// cycleNewRoot wählt das nächste ADR-Verzeichnis für einen neuen ADR.
+func (m *model) cycleNewRoot() {
+	cur := m.newRootDir()
+	for i, r := range adrRoots {
+		if r.Dir == cur {
+			m.newRoot = adrRoots[(i+1)%len(adrRoots)].Dir
+			return
+		}
+	}
+	m.newRoot = adrRoots[0].Dir
+}
+
+// canChooseRoot: nur neue ADRs ohne festes Ziel und nur im Monorepo.
+func (m model) canChooseRoot() bool {
+	return multiRoot() && m.editingPath == "" && m.targetDir == ""
+}
+
+// newRootDir: Zielverzeichnis für einen neuen ADR.
+func (m model) newRootDir() string {
+	if m.newRoot != "" {
+		return m.newRoot
+	}
+	return defaultNewRoot().Dir
+}
+
+// describeRoot für Anzeigen wie "billing (services/billing/docs/adr)".
+func describeRoot(dir string) string {
+	r, ok := rootFor(filepath.Join(dir, "x"))
+	switch {
+	case !ok:
+		return dir
+	case r.NS == "":
+		return "Haupt-Verzeichnis (" + displayDir(dir) + ")"
+	}
+	return r.NS + " (" + filepath.ToSlash(dir) + ")"
+}
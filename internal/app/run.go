@@ -1,10 +1,62 @@
 package app
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"fmt"
+	"os"
+	"strings"
 
-func Run() error {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
-	_, err := p.Run()
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Run startet die TUI oder, falls args mit einem Unterbefehl beginnt, die
+// entsprechende Kommandozeilen-Funktion.
+func Run(args []string) error {
+	dir, args, err := globalFlags(args)
+	if err != nil {
+		return err
+	}
+	// Der Katalog gehört zu keinem Repository und läuft auch außerhalb.
+	if len(args) > 0 && args[0] == "catalog" {
+		if startDir, err = os.Getwd(); err != nil {
+			return err
+		}
+		return runCatalog(args[1:])
+	}
+	if err := initWorkspace(dir); err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd(args[1:])
+		}
+	}
+	m := initialModel()
+	if !m.startup { // direkt im Editor für einen neuen Entwurf
+		_ = m.acquireLock(fileOption{Path: m.draftFixedPath, Draft: true})
+	}
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	fm, err := p.Run()
+	if m, ok := fm.(model); ok {
+		m.releaseLock()
+	}
 	return err
 }
 
+// globalFlags liest Optionen vor dem Unterbefehl, derzeit nur --dir.
+func globalFlags(args []string) (dir string, rest []string, err error) {
+	for len(args) > 0 {
+		a := args[0]
+		switch {
+		case a == "--dir" || a == "-dir":
+			if len(args) < 2 {
+				return "", nil, fmt.Errorf("%s: Verzeichnis fehlt", a)
+			}
+			dir, args = args[1], args[2:]
+		case strings.HasPrefix(a, "--dir="):
+			dir, args = strings.TrimPrefix(a, "--dir="), args[1:]
+		default:
+			return dir, args, nil
+		}
+	}
+	return dir, args, nil
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -20,52 +21,132 @@ func saveCmd(m model) tea.Cmd {
 func (m model) handleSaveDone(msg saveDoneMsg) (model, tea.Cmd) {
 	m.saving = false
 	m.err = msg.err
+	var ce *conflictError
+	if errors.As(msg.err, &ce) {
+		m.err = nil
+		m.confirming = false
+		m.merge = buildMerge(m, ce)
+		return m, nil
+	}
 	if msg.err == nil {
 		fmt.Println(okStyle.Render("✔ ADR gespeichert: ") + msg.path)
 		// Draft entfernen, wenn vorhanden
 		if dp := m.draftPath(); dp != "" {
 			_ = os.Remove(dp)
+			removeDraftSidecars(dp)
 		}
+		m.releaseLock()
 		return m, tea.Quit
 	}
 	return m, nil
 }
 
 func writeADR(m model) (string, error) {
-	dir := "."
-	if err := ensureDir(dir); err != nil {
+	if err := checkExternal(m); err != nil {
+		return "", err
+	}
+	path, content, err := renderADR(m)
+	if err != nil {
 		return "", err
 	}
+	var refs []fileEdit
+	if op, ok := m.pendingRename(); ok {
+		plan, err := planRenames([]renameOp{op})
+		if err != nil {
+			return "", err
+		}
+		refs = plan.refEdits()
+	}
+	if err := checkLock(m.lock); err != nil {
+		return "", err
+	}
+	if err := publishADR(m.editingPath, path, content); err != nil {
+		return "", err
+	}
+	if err := writeEdits(refs); err != nil {
+		return path, fmt.Errorf("gespeichert, aber Verweise nicht angepasst: %w", err)
+	}
+	return path, nil
+}
+
+// existingTarget: Zielpfad eines bestehenden ADR in dir – mit Titel wird
+// der Dateiname neu gebildet.
+func (m model) existingTarget(dir string) string {
+	path := m.editingPath
+	if title := strings.TrimSpace(m.Title()); title != "" {
+		return filepath.Join(dir, fmt.Sprintf("ADR-%04d-%s.md", m.editingNo, slugify(title)))
+	}
+	if filepath.Dir(path) != filepath.Clean(dir) {
+		return filepath.Join(dir, filepath.Base(path))
+	}
+	return path
+}
+
+// pendingRename: die Umbenennung, die beim Speichern eines bestehenden ADR
+// ansteht (neuer Titel oder anderes Zielverzeichnis).
+func (m model) pendingRename() (renameOp, bool) {
+	if m.editingPath == "" {
+		return renameOp{}, false
+	}
+	dir := m.targetDir
+	if dir == "" {
+		dir = filepath.Dir(m.editingPath)
+	}
+	to := m.existingTarget(dir)
+	if to == m.editingPath {
+		return renameOp{}, false
+	}
+	r, _ := rootFor(m.editingPath)
+	return renameOp{
+		Old: m.editingPath, New: to,
+		OldNo: m.editingNo, NewNo: m.editingNo,
+		OldTitle: parseADRForSearch(m.editingPath).Title, NewTitle: strings.TrimSpace(m.Title()),
+		NS: r.NS,
+	}, true
+}
+
+// renderADR bestimmt Zielpfad und Markdown-Inhalt, ohne etwas zu schreiben.
+func renderADR(m model) (path, content string, err error) {
+	dir := m.targetDir
+	if dir == "" {
+		dir = m.newRootDir()
+		if m.editingPath != "" {
+			dir = filepath.Dir(m.editingPath) // bestehende Dateien bleiben, wo sie sind
+		}
+	}
+	if err := ensureDir(dir); err != nil {
+		return "", "", err
+	}
 
 	no := m.editingNo
-	oldPath := m.editingPath
-	path := oldPath
+	path = m.editingPath
 	title := strings.TrimSpace(m.Title())
 
-	if path == "" {
-		var err error
-		no, err = nextADRNumber(dir)
+	if path != "" {
+		path = m.existingTarget(dir)
+	} else {
+		no, err = allocateNumber(dir, m.gitName)
 		if err != nil {
-			return "", err
+			return "", "", err
 		}
 		slug := noTitleSlug()
 		if title != "" {
 			slug = slugify(title)
 		}
 		path = filepath.Join(dir, fmt.Sprintf("ADR-%04d-%s.md", no, slug))
-	} else if title != "" {
-		desired := filepath.Join(dir, fmt.Sprintf("ADR-%04d-%s.md", no, slugify(title)))
-		if desired != path {
-			path = desired
-		}
 	}
 
+	return path, savedMarkdown(m, no), nil
+}
+
+// savedMarkdown ist der Inhalt, den renderADR für Nummer no schreibt – mit
+// heutigem Bearbeitungsdatum.
+func savedMarkdown(m model, no int) string {
 	now := time.Now().Format("2006-01-02")
 
 	created := strings.TrimSpace(m.createdDate)
 	if created == "" {
 		created = now
-		m.createdDate = created
 	}
 
 	by := strings.TrimSpace(m.gitName)
@@ -76,29 +157,19 @@ func writeADR(m model) (string, error) {
 			by = "Unbekannt"
 		}
 	}
-	editedAt := now
-	m.lastEditedBy = by
-	m.lastEditedAt = editedAt
 
-	content := buildMarkdown(
+	author, key := m.authorship()
+	return buildMarkdown(
 		no,
 		m.Title(),
 		created,
 		m.Status(),
 		m.Beteiligte(),
 		m.Tags(),
-		m.gitName, m.gitEmail, m.gitSigningKey,
-		by, editedAt,
-		m.Kontext(), m.Entscheidung(), m.Alternativen(), m.Konsequenzen(),
+		author, key,
+		by, now,
+		m.Kontext(), m.Entscheidung(), m.Alternativen(), m.Konsequenzen(), m.pruefregeln, m.verweise,
 	)
-
-	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
-		return "", err
-	}
-	if oldPath != "" && oldPath != path {
-		_ = os.Remove(oldPath)
-	}
-	return path, nil
 }
 
 func buildMarkdownPreview(m model) string {
@@ -124,30 +195,43 @@ func buildMarkdownPreview(m model) string {
 		editedAt = today
 	}
 
-	md := buildMarkdown(
+	author, key := m.authorship()
+	return buildMarkdown(
 		no,
 		m.Title(),
 		created,
 		m.Status(),
 		m.Beteiligte(),
 		m.Tags(),
-		m.gitName, m.gitEmail, m.gitSigningKey,
+		author, key,
 		by, editedAt,
-		m.Kontext(), m.Entscheidung(), m.Alternativen(), m.Konsequenzen(),
+		m.Kontext(), m.Entscheidung(), m.Alternativen(), m.Konsequenzen(), m.pruefregeln, m.verweise,
 	)
-	lines := strings.Split(md, "\n")
-	if len(lines) > 20 {
-		lines = lines[:20]
+}
+
+// authorship: Autor und Signing-Key bleiben, wie sie in der Datei stehen;
+// ohne Autor-Zeile (neuer ADR) kommen sie aus git config.
+func (m model) authorship() (author, signingKey string) {
+	if a := strings.TrimSpace(m.autor); a != "" {
+		return a, strings.TrimSpace(m.signingKey)
 	}
-	return strings.Join(lines, "\n")
+	switch {
+	case m.gitName != "" && m.gitEmail != "":
+		author = fmt.Sprintf("%s <%s>", m.gitName, m.gitEmail)
+	case m.gitName != "":
+		author = m.gitName
+	default:
+		author = m.gitEmail
+	}
+	return author, m.gitSigningKey
 }
 
 func buildMarkdown(
 	no int,
 	title, createdDate, status, beteiligte, tags string,
-	authorName, authorEmail, signingKey string,
+	author, signingKey string,
 	lastEditedBy, lastEditedAt string,
-	kontext, entscheidung, alternativen, konsequenzen string,
+	kontext, entscheidung, alternativen, konsequenzen, pruefregeln, verweise string,
 ) string {
 	noTitle := title
 	if no > 0 {
@@ -168,16 +252,7 @@ func buildMarkdown(
 	// Status
 	fmt.Fprintf(b, "| Status | %s |\n", status)
 
-	// Autor (aus git config)
-	author := ""
-	switch {
-	case authorName != "" && authorEmail != "":
-		author = fmt.Sprintf("%s <%s>", authorName, authorEmail)
-	case authorName != "":
-		author = authorName
-	case authorEmail != "":
-		author = authorEmail
-	}
+	// Autor
 	if strings.TrimSpace(author) != "" {
 		fmt.Fprintf(b, "| Autor | %s |\n", author)
 	}
@@ -227,7 +302,14 @@ func buildMarkdown(
 	}
 	b.WriteString(konsequenzen + "\n\n")
 
-	b.WriteString("## Verweise\n- \n")
+	if strings.TrimSpace(pruefregeln) != "" {
+		b.WriteString("## " + rulesHeading + "\n" + pruefregeln + "\n\n")
+	}
+
+	if strings.TrimSpace(verweise) == "" {
+		verweise = "- "
+	}
+	b.WriteString("## Verweise\n" + verweise + "\n")
 	return b.String()
 }
 
@@ -276,5 +358,25 @@ func (m model) buildSaveSummary() (summary string, missing []string) {
 	} else {
 		fmt.Fprintf(b, "• Titel: „%s“\n", title)
 	}
+	if p := m.refPlan; p != nil {
+		for _, op := range p.Ops {
+			fmt.Fprintf(b, "• Umbenennung: %s → %s\n", filepath.Base(op.Old), filepath.Base(op.New))
+		}
+		if refs := p.refEdits(); len(refs) > 0 {
+			names := make([]string, len(refs))
+			for i, e := range refs {
+				names[i] = e.Path
+			}
+			fmt.Fprintf(b, "• Verweise werden angepasst in: %s\n", strings.Join(names, ", "))
+		}
+	}
+	if m.editingPath == "" && m.targetDir == "" {
+		if multiRoot() {
+			fmt.Fprintf(b, "• Ziel: %s\n", describeRoot(m.newRootDir()))
+		}
+		if w := placementWarning(m.newRootDir()); w != "" {
+			fmt.Fprintf(b, "\n%s\n", errorStyle.Render("⚠ "+w))
+		}
+	}
 	return b.String(), missing
 }
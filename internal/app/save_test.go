@@ -0,0 +1,60 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+// Speichern schreibt Autor, Signing-Key und Verweise so zurück, wie sie in
+// der Datei stehen – auch nach einer Bearbeitung im externen Editor.
+func TestSaveKeepsAuthorAndVerweise(t *testing.T) {
+	const path = "ADR-0007-wahl-des-service-mesh.md"
+	m := newBlankModel()
+	m.gitName, m.gitEmail, m.gitSigningKey = "Mallory", "m@y", "FFFF"
+	m.fillFromParsed(parseADRText(path, bulkFixture))
+	m.editingNo = 7
+
+	got := savedMarkdown(m, 7)
+	for _, want := range []string{
+		"| Autor | Alice <a@x> |\n",
+		"| Signing-Key | ABCDEF |\n",
+		"| Zuletzt editiert von | Mallory |\n",
+		"## Verweise\n- [ADR-0003](ADR-0003-netz.md)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+
+	edited := strings.NewReplacer(
+		"| Autor | Alice <a@x> |", "| Autor | Bob <b@x> |",
+		"- [ADR-0003](ADR-0003-netz.md)", "- [ADR-0003](ADR-0003-netz.md)\n- [ADR-0004](ADR-0004-dns.md)",
+	).Replace(buildMarkdownPreview(m))
+	m.applyADRText(edited)
+	got = savedMarkdown(m, 7)
+	for _, want := range []string{
+		"| Autor | Bob <b@x> |\n",
+		"## Verweise\n- [ADR-0003](ADR-0003-netz.md)\n- [ADR-0004](ADR-0004-dns.md)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("after external edit: missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+// Ein neuer ADR bekommt Autor und Signing-Key aus git config und den
+// Verweise-Platzhalter.
+func TestSaveNewADRUsesGitIdentity(t *testing.T) {
+	m := newBlankModel()
+	m.gitName, m.gitEmail, m.gitSigningKey = "Mallory", "m@y", "FFFF"
+	m.title.SetValue("Neu")
+	got := savedMarkdown(m, 1)
+	for _, want := range []string{"| Autor | Mallory <m@y> |\n", "| Signing-Key | FFFF |\n", "## Verweise\n- \n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if p := parseADRText("ADR-0001-neu.md", got); p.Verweise != "" {
+		t.Errorf("placeholder parsed as Verweise %q", p.Verweise)
+	}
+}
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+/* --------------- Speichern: vollständige Vorschau zum Blättern ------------ */
+
+// previewPane liefert den Viewport des Speichern-Schritts mit aktuellem
+// Inhalt und passender Höhe; gespeichert wird nur die Scroll-Position.
+func (m model) previewPane() viewport.Model {
+	vp := m.preview
+	vp.Width = max(20, m.width-2*framePadding)
+	var md string
+	switch {
+	case m.previewDiff:
+		md = m.cachedSaveDiff().out
+	case m.previewRaw:
+		md = buildMarkdownPreview(m)
+	default:
+		md = renderMarkdown(buildMarkdownPreview(m), vp.Width)
+	}
+	vp.Height = strings.Count(md, "\n") + 1
+	if m.height > 0 {
+		chrome := lipgloss.Height(m.header()) + 2 + lipgloss.Height(m.saveFooter()) // "Speichern" + Titelzeile
+		vp.Height = max(3, min(vp.Height, m.height-chrome))
+	}
+	vp.SetContent(md)
+	return vp
+}
+
+// previewKey blättert in der Vorschau oder schaltet die Darstellung um.
+func (m *model) previewKey(k string) bool {
+	vp := m.previewPane()
+	switch k {
+	case "up":
+		vp.ScrollUp(1)
+	case "down":
+		vp.ScrollDown(1)
+	case "pgup":
+		vp.PageUp()
+	case "pgdown":
+		vp.PageDown()
+	case "home":
+		vp.GotoTop()
+	case "end":
+		vp.GotoBottom()
+	case "r", "R":
+		if m.previewDiff {
+			return false
+		}
+		m.previewRaw = !m.previewRaw
+		return true
+	case "d", "D":
+		if m.editingPath == "" {
+			return false
+		}
+		m.previewDiff = !m.previewDiff
+		m.preview.GotoTop()
+		m.refreshSaveDiff()
+		return true
+	default:
+		return false
+	}
+	m.preview = vp
+	return true
+}
+
+func (m model) previewTitle(vp viewport.Model) string {
+	mode := "Vorschau (formatiert)"
+	switch {
+	case m.previewDiff:
+		d := m.cachedSaveDiff()
+		mode = fmt.Sprintf("Änderungen gegenüber der Datei (+%d −%d)", d.add, d.del)
+	case m.previewRaw:
+		mode = "Vorschau (Markdown)"
+	}
+	if !vp.AtTop() || !vp.AtBottom() {
+		mode += fmt.Sprintf(" · %d %%", int(vp.ScrollPercent()*100))
+	}
+	return labelStyle.Render(mode)
+}
+
+// saveFooter: Überprüfung bzw. Ziel und Tastenhilfe unter der Vorschau.
+func (m model) saveFooter() string {
+	scroll := " · ↑/↓ BILD↑/↓ blättern"
+	if m.previewRaw && !m.previewDiff {
+		scroll += " · R formatiert"
+	} else if !m.previewDiff {
+		scroll += " · R Markdown"
+	}
+	if m.editingPath != "" {
+		if m.previewDiff {
+			scroll += " · D Vorschau"
+		} else {
+			scroll += " · D Änderungen"
+		}
+	}
+	var b strings.Builder
+	if m.confirming {
+		sum, missing := m.buildSaveSummary()
+		b.WriteString("\n" + labelStyle.Render("Überprüfung") + "\n")
+		b.WriteString(strings.TrimRight(sum, "\n"))
+		if len(missing) > 0 {
+			b.WriteString("\n" + errorStyle.Render("Fehlt/leer: "+strings.Join(missing, ", ")))
+		}
+		keys := "J/ENTER speichern · N/B/ESC abbrechen"
+		if m.canChooseRoot() {
+			keys += " · Z Ziel wechseln"
+		}
+		b.WriteString("\n\n" + m.help(keys+scroll))
+		return b.String()
+	}
+	keys := "S Speicherdialog öffnen · B/SHIFT+TAB zurück · ESC/STRG+C abbrechen"
+	if m.canChooseRoot() {
+		b.WriteString("\n" + labelStyle.Render("Ziel: ") + describeRoot(m.newRootDir()) + "\n")
+		keys += " · Z Ziel wechseln"
+	}
+	b.WriteString("\n" + m.help(keys+scroll))
+	return b.String()
+}
+
+type savedDiff struct {
+	out      string
+	add, del int
+}
+
+// refreshSaveDiff berechnet den Diff beim Betreten des Speichern-Schritts
+// und nach Änderungen neu statt bei jedem Neuzeichnen.
+func (m *model) refreshSaveDiff() {
+	m.diff = nil
+	if m.step == 8 && m.previewDiff && m.editingPath != "" {
+		out, add, del := m.saveDiff()
+		m.diff = &savedDiff{out: out, add: add, del: del}
+	}
+}
+
+func (m model) cachedSaveDiff() savedDiff {
+	if m.diff != nil {
+		return *m.diff
+	}
+	out, add, del := m.saveDiff()
+	return savedDiff{out: out, add: add, del: del}
+}
+
+// saveDiff vergleicht die Datei auf der Platte mit dem, was beim Speichern
+// geschrieben wird – samt Umbenennung, falls sich der Titel geändert hat.
+func (m model) saveDiff() (out string, add, del int) {
+	to := m.editingPath
+	if op, ok := m.pendingRename(); ok {
+		to = op.New
+	}
+	var b strings.Builder
+	b.WriteString(diffDelStyle.Render("--- "+filepath.ToSlash(m.editingPath)) + "\n")
+	b.WriteString(diffAddStyle.Render("+++ "+filepath.ToSlash(to)) + "\n")
+	if to != m.editingPath {
+		b.WriteString(labelStyle.Render("umbenannt: ") + filepath.Base(m.editingPath) + " → " + filepath.Base(to) + "\n")
+	}
+	old, err := os.ReadFile(m.editingPath)
+	if err != nil {
+		b.WriteString(errorStyle.Render("Datei nicht lesbar: ") + err.Error())
+		return b.String(), 0, 0
+	}
+	ld := lineDiff(splitLines(strings.TrimRight(string(old), "\n")), splitLines(strings.TrimRight(savedMarkdown(m, m.editingNo), "\n")))
+	if !diffChanged(ld) {
+		b.WriteString(helpStyle.Render("Inhalt unverändert"))
+		return b.String(), 0, 0
+	}
+	add, del = diffStat(ld)
+	b.WriteString(renderSectionDiff(ld, 2))
+	return b.String(), add, del
+}
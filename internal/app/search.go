@@ -32,12 +32,17 @@ func (m *model) applyFilter(q string) {
 	}
 
 	out := make([]fileOption, 0, len(base))
-	out = append(out, base[0]) // "+ Neuer ADR" immer oben
+	pinned := 0
+	if base[0].Path == newAdrSentinel {
+		out = append(out, base[0]) // "+ Neuer ADR" immer oben
+		pinned = 1
+	}
 	m.hitBadges = make(map[string][]badge)
 	m.hitSnippet = make(map[string]string)
 
 	if q == "" {
-		out = append(out, base[1:]...)
+		out = append(out, base[pinned:]...)
+		m.sortPicks(out[pinned:])
 		m.pickOptions = out
 		if m.pickIdx >= len(m.pickOptions) {
 			m.pickIdx = 0
@@ -53,7 +58,7 @@ func (m *model) applyFilter(q string) {
 	}
 	hits := []scored{}
 
-	for _, opt := range base[1:] { // sentinel überspringen
+	for _, opt := range base[pinned:] { // sentinel überspringen
 		doc := m.searchDocs[opt.Path]
 		label := strings.ToLower(opt.Label)
 
@@ -155,6 +160,9 @@ func (m *model) applyFilter(q string) {
 
 	sort.Slice(hits, func(i, j int) bool {
 		if hits[i].score == hits[j].score {
+			if hits[i].opt.NS != hits[j].opt.NS {
+				return hits[i].opt.NS < hits[j].opt.NS
+			}
 			if hits[i].opt.No == hits[j].opt.No {
 				return hits[i].opt.Label < hits[j].opt.Label
 			}
@@ -172,6 +180,7 @@ func (m *model) applyFilter(q string) {
 	for _, h := range hits {
 		out = append(out, h.opt)
 	}
+	m.sortPicks(out[pinned:])
 	m.pickOptions = out
 	if m.pickIdx >= len(m.pickOptions) {
 		m.pickIdx = 0
@@ -200,24 +209,37 @@ func buildSearchDocs(opts []fileOption) map[string]searchDoc {
 			continue
 		}
 		var d searchDoc
-		if strings.HasSuffix(o.Path, ".md") {
+		if o.Rev != "" {
+			d = parseHistoricForSearch(o.Path)
+		} else if strings.HasSuffix(o.Path, ".md") {
 			d = parseADRForSearch(o.Path)
 		} else if strings.HasSuffix(o.Path, ".draft.json") {
 			d = parseDraftForSearch(o.Path)
 		}
-		// Fulltext (alles kleingeschrieben)
-		var sb strings.Builder
-		sb.WriteString(strings.ToLower(o.Label) + " ")
-		sb.WriteString(strings.ToLower(d.Title) + " ")
-		sb.WriteString(strings.ToLower(d.Status) + " ")
-		sb.WriteString(strings.ToLower(d.Beteiligte) + " ")
-		sb.WriteString(strings.ToLower(d.Tags) + " ")
-		sb.WriteString(strings.ToLower(d.Kontext) + " ")
-		sb.WriteString(strings.ToLower(d.Entscheidung) + " ")
-		sb.WriteString(strings.ToLower(d.Alternativen) + " ")
-		sb.WriteString(strings.ToLower(d.Konsequenzen))
-		d.Full = sb.String()
-		idx[o.Path] = d
+		idx[o.Path] = withFullText(o, d)
 	}
 	return idx
 }
+
+// withFullText befüllt searchDoc.Full für die Volltextsuche.
+func withFullText(o fileOption, d searchDoc) searchDoc {
+	// Fulltext (alles kleingeschrieben)
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(o.Label) + " ")
+	if o.No > 0 {
+		sb.WriteString(strings.ToLower(adrID(o.NS, o.No)) + " ")
+	}
+	if o.Repo != "" {
+		sb.WriteString(strings.ToLower(o.Repo) + " ")
+	}
+	sb.WriteString(strings.ToLower(d.Title) + " ")
+	sb.WriteString(strings.ToLower(d.Status) + " ")
+	sb.WriteString(strings.ToLower(d.Beteiligte) + " ")
+	sb.WriteString(strings.ToLower(d.Tags) + " ")
+	sb.WriteString(strings.ToLower(d.Kontext) + " ")
+	sb.WriteString(strings.ToLower(d.Entscheidung) + " ")
+	sb.WriteString(strings.ToLower(d.Alternativen) + " ")
+	sb.WriteString(strings.ToLower(d.Konsequenzen))
+	d.Full = sb.String()
+	return d
+}
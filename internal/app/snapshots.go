@@ -0,0 +1,243 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+/* ---------------- Versionshistorie der Entwürfe (Zeitreise) --------------- */
+
+const (
+	snapshotKeep        = 20 // letzte unterschiedliche Stände
+	snapshotCheckpoints = 48 // danach ein Stand pro Stunde
+)
+
+type snapshot struct {
+	At    time.Time
+	Hash  string
+	Path  string
+	Draft draftFile
+}
+
+// snapshotDir: ein Unterordner je Entwurf.
+func snapshotDir(draftPath string) string {
+	return snapshotDirIn(autosaveDir, filepath.Base(draftPath))
+}
+
+func snapshotDirIn(dir, draftName string) string {
+	return filepath.Join(dir, "history", strings.TrimSuffix(draftName, ".draft.json"))
+}
+
+// draftContentHash ignoriert Zeitstempel und Merge-Basis, damit nur echte
+// Inhaltsänderungen einen neuen Stand erzeugen.
+func draftContentHash(df draftFile) string {
+	df.SavedAt = time.Time{}
+	df.BaseHash, df.BaseContent, df.BaseModTime = "", "", time.Time{}
+	b, _ := json.Marshal(df)
+	return contentHash(string(b))
+}
+
+// listSnapshots liefert die Stände eines Entwurfs, neueste zuerst. Mit
+// withDraft werden auch die Inhalte gelesen.
+func listSnapshots(draftPath string, withDraft bool) []snapshot {
+	dir := snapshotDir(draftPath)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var out []snapshot
+	for _, e := range entries {
+		stamp, hash, ok := strings.Cut(strings.TrimSuffix(e.Name(), ".json"), "-")
+		ns, err := strconv.ParseInt(stamp, 10, 64)
+		if e.IsDir() || !ok || err != nil {
+			continue
+		}
+		s := snapshot{At: time.Unix(0, ns), Hash: hash, Path: filepath.Join(dir, e.Name())}
+		if withDraft {
+			if s.Draft, err = readDraftFile(s.Path); err != nil {
+				continue
+			}
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].At.After(out[j].At) })
+	return out
+}
+
+// recordSnapshot legt einen neuen Stand an, sofern sich der Inhalt seit dem
+// letzten geändert hat, und dünnt die Historie aus.
+func recordSnapshot(draftPath string, df draftFile) error {
+	hash := draftContentHash(df)[:16]
+	snaps := listSnapshots(draftPath, false)
+	if len(snaps) > 0 && snaps[0].Hash == hash {
+		return nil
+	}
+	name := fmt.Sprintf("%d-%s.json", time.Now().UnixNano(), hash)
+	if err := writeDraft(filepath.Join(snapshotDir(draftPath), name), df); err != nil {
+		return err
+	}
+	pruneSnapshots(draftPath)
+	return nil
+}
+
+// pruneSnapshots behält die letzten snapshotKeep Stände und davor den
+// jeweils jüngsten Stand jeder Stunde.
+func pruneSnapshots(draftPath string) {
+	snaps := listSnapshots(draftPath, false)
+	hours := map[string]bool{}
+	for i, s := range snaps {
+		if i < snapshotKeep {
+			continue
+		}
+		h := s.At.Format("2006010215")
+		if !hours[h] && len(hours) < snapshotCheckpoints {
+			hours[h] = true
+			continue
+		}
+		_ = os.Remove(s.Path)
+	}
+}
+
+func removeSnapshots(draftPath string) {
+	_ = os.RemoveAll(snapshotDir(draftPath))
+}
+
+// draftSections: vergleichbare Abschnitte eines Entwurfs, in der Reihenfolge
+// von mergeSectionNames.
+func draftSections(df draftFile) []string {
+	status := ""
+	if df.StatusIdx >= 0 && df.StatusIdx < len(statuses) {
+		status = statuses[df.StatusIdx]
+	}
+	return []string{
+		df.Title, status, df.Kontext,
+		strings.Join(df.Entscheidung, "\n"), strings.Join(df.Alternativen, "\n"), strings.Join(df.Konsequenzen, "\n"),
+		df.Beteiligte, df.Tags, df.Pruefregeln, df.Verweise,
+	}
+}
+
+// changedSections nennt die Abschnitte, in denen sich a und b unterscheiden.
+func changedSections(a, b draftFile) []string {
+	sa, sb := draftSections(a), draftSections(b)
+	var out []string
+	for i := range sa {
+		if strings.TrimSpace(sa[i]) != strings.TrimSpace(sb[i]) {
+			out = append(out, mergeSectionNames[i])
+		}
+	}
+	return out
+}
+
+/* --------------------------------- Ansicht -------------------------------- */
+
+type snapshotView struct {
+	snaps []snapshot
+	idx   int
+}
+
+func (m model) openSnapshots() (model, tea.Cmd) {
+	m.snaps = &snapshotView{snaps: listSnapshots(m.draftPath(), true)}
+	return m, nil
+}
+
+// restoreSnapshot übernimmt den Inhalt eines Stands. Der aktuelle Stand wird
+// vorher selbst gesichert, Pfad und Merge-Basis bleiben unverändert; CTRL+Z
+// macht das Wiederherstellen rückgängig.
+func (m *model) restoreSnapshot(s snapshot) {
+	before := m.editState()
+	cur := m.toDraft()
+	_ = recordSnapshot(m.draftPath(), cur)
+	d := s.Draft
+	d.EditingPath, d.EditingNo = cur.EditingPath, cur.EditingNo
+	d.BaseHash, d.BaseModTime, d.BaseContent = cur.BaseHash, cur.BaseModTime, cur.BaseContent
+	m.applyDraft(d)
+	m.trackUndo(before, tea.KeyMsg{})
+}
+
+func (m model) updateSnapshots(msg tea.KeyMsg) (model, tea.Cmd) {
+	sv := m.snaps
+	switch msg.String() {
+	case "up", "ctrl+p", "k":
+		if sv.idx > 0 {
+			sv.idx--
+		}
+	case "down", "ctrl+n", "j":
+		if sv.idx < len(sv.snaps)-1 {
+			sv.idx++
+		}
+	case "enter", "r":
+		if len(sv.snaps) == 0 {
+			return m, nil
+		}
+		m.restoreSnapshot(sv.snaps[sv.idx])
+		m.snaps = nil
+		return m, m.focusForStep()
+	case "esc", "f2":
+		m.snaps = nil
+	}
+	return m, nil
+}
+
+func (m model) viewSnapshots() string {
+	sv := m.snaps
+	if len(sv.snaps) == 0 {
+		return helpStyle.Render("Für diesen Entwurf gibt es noch keine gespeicherten Stände – das Autosave legt sie alle paar Sekunden an.") +
+			"\n\n" + m.help("ESC/F2 zurück")
+	}
+	cur := m.toDraft()
+	var list strings.Builder
+	list.WriteString(labelStyle.Render("Gespeicherte Stände") + "\n")
+	h := len(sv.snaps)
+	if m.height > 0 {
+		h = max(5, m.height-12)
+	}
+	from := max(0, sv.idx-h+1)
+	for i, s := range sv.snaps {
+		if i < from || i >= from+h {
+			continue
+		}
+		what := "Anfang"
+		if i+1 < len(sv.snaps) {
+			what = strings.Join(changedSections(sv.snaps[i+1].Draft, s.Draft), ", ")
+		}
+		st := optionStyle
+		if i == sv.idx {
+			st = selectedStyle
+		}
+		list.WriteString(st.Render(fmt.Sprintf("%s  %s", s.At.Format("02.01. 15:04:05"), fitCell(what, 28))) + "\n")
+	}
+
+	sel := sv.snaps[sv.idx]
+	var d strings.Builder
+	d.WriteString(labelStyle.Render("Unterschied zum aktuellen Stand") + "\n")
+	old, now := draftSections(sel.Draft), draftSections(cur)
+	for i, name := range mergeSectionNames {
+		ld := lineDiff(splitLines(old[i]), splitLines(now[i]))
+		if !diffChanged(ld) {
+			continue
+		}
+		d.WriteString("\n" + labelStyle.Render(name) + "\n" + renderDiff(ld, 2) + "\n")
+	}
+	if len(changedSections(sel.Draft, cur)) == 0 {
+		d.WriteString(helpStyle.Render("identisch mit dem aktuellen Stand"))
+	}
+
+	listW := 48
+	diffW := max(30, m.width-2*framePadding-listW-2)
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(listW).Render(list.String()),
+		"  ",
+		lipgloss.NewStyle().Width(diffW).Render(strings.TrimRight(d.String(), "\n")),
+	)
+	return body + "\n\n" + m.help("↑/↓ Stand wählen · ENTER/R wiederherstellen (aktueller Stand bleibt gesichert) · ESC/F2 zurück") +
+		"\n" + helpStyle.Render("- im Stand, + aktuell")
+}
@@ -50,6 +50,14 @@ var (
 		"Alternativen": gbAqua,
 		"Konsequenzen": gbRed,
 		"Dateiname":    gbGray,
+		historicBadge:  gbGray,
+	}
+
+	statusColors = map[string]string{
+		"Vorgeschlagen": gbYellow,
+		"Angenommen":    gbGreen,
+		"Abgelehnt":     gbRed,
+		"Veraltet":      gbGray,
 	}
 
 	snippetStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
@@ -65,3 +73,12 @@ func chipWithCount(b badge) string {
 	// {Label} [Count]
 	return st.Render(fmt.Sprintf("{%s} [%d]", b.Label, b.Count))
 }
+
+// chip rendert ein Badge ohne Trefferzahl (z. B. "historisch").
+func chip(label string) string {
+	st := chipBase
+	if bg := chipColors[label]; bg != "" {
+		st = st.Background(lipgloss.Color(bg))
+	}
+	return st.Render(label)
+}
@@ -0,0 +1,228 @@
+package app
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+/* ------------------- Picker als Tabelle: Spalten + Sortierung -------------- */
+
+type sortColumn int
+
+const (
+	sortDefault sortColumn = iota // Relevanz bei Suche, sonst Nummer
+	sortNo
+	sortTitle
+	sortStatus
+	sortCreated
+	sortEdited
+	sortTags
+	sortColumnCount
+)
+
+var sortColumnNames = map[sortColumn]string{
+	sortDefault: "Standard",
+	sortNo:      "Nr.",
+	sortTitle:   "Titel",
+	sortStatus:  "Status",
+	sortCreated: "Erstellt",
+	sortEdited:  "Zuletzt",
+	sortTags:    "Tags",
+}
+
+// tableColumn: sort ist zugleich der Schlüssel der Spalte.
+type tableColumn struct {
+	title string
+	sort  sortColumn
+	width int // 0 = Restbreite
+}
+
+// pickColumns verteilt die verfügbare Breite; schmale Terminals verlieren
+// zuerst "Zuletzt", dann "Tags".
+func (m model) pickColumns(w int) []tableColumn {
+	cols := []tableColumn{
+		{title: "Nr.", sort: sortNo, width: m.noColumnWidth()},
+		{title: "Titel", sort: sortTitle},
+		{title: "Status", sort: sortStatus, width: 14},
+		{title: "Erstellt", sort: sortCreated, width: 11},
+		{title: "Zuletzt", sort: sortEdited, width: 24},
+		{title: "Tags", sort: sortTags, width: 18},
+	}
+	if w <= 0 {
+		w = 120
+	}
+	fixed := func() int {
+		n := 0
+		for _, c := range cols {
+			n += c.width + 1
+		}
+		return n
+	}
+	for len(cols) > 4 && w-fixed() < 20 {
+		cols = append(cols[:4], cols[5:]...) // "Zuletzt" zuerst, dann "Tags"
+	}
+	for i := range cols {
+		if cols[i].width == 0 {
+			cols[i].width = max(10, w-fixed()-1)
+		}
+	}
+	return cols
+}
+
+func (m model) renderTableHeader(w int) string {
+	var cells []string
+	for _, c := range m.pickColumns(w) {
+		t := c.title
+		if c.sort != sortDefault && c.sort == m.sortCol {
+			if m.sortDesc {
+				t += " ▼"
+			} else {
+				t += " ▲"
+			}
+		}
+		cells = append(cells, labelStyle.Render(fitCell(t, c.width)))
+	}
+	return strings.Join(cells, " ")
+}
+
+func (m model) renderTableRow(opt fileOption, st lipgloss.Style, w int) string {
+	if opt.Path == newAdrSentinel {
+		return st.Render(opt.Label)
+	}
+	doc := m.searchDocs[opt.Path]
+	var cells []string
+	for _, c := range m.pickColumns(w) {
+		val := ""
+		cs := st
+		switch c.sort {
+		case sortNo:
+			val = opt.number()
+		case sortTitle:
+			val = doc.Title
+			if val == "" || opt.Draft {
+				val = opt.Label
+			}
+		case sortStatus:
+			val = doc.Status
+			if col := statusColors[val]; col != "" {
+				cs = cs.Foreground(lipgloss.Color(col))
+			}
+		case sortCreated:
+			val = doc.CreatedDate
+		case sortEdited:
+			val = strings.TrimSpace(doc.LastEditedAt + " " + doc.LastEditedBy)
+		case sortTags:
+			val = doc.Tags
+		}
+		cells = append(cells, cs.Render(fitCell(val, c.width)))
+	}
+	return strings.Join(cells, " ")
+}
+
+// fitCell kürzt bzw. füllt s auf genau w Zellen.
+func fitCell(s string, w int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if lipgloss.Width(s) <= w {
+		return s + strings.Repeat(" ", w-lipgloss.Width(s))
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		rw := lipgloss.Width(string(r))
+		if used+rw > w-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += rw
+	}
+	b.WriteString("…")
+	used++
+	return b.String() + strings.Repeat(" ", max(0, w-used))
+}
+
+// sortPicks ordnet die Treffer nach der gewählten Spalte; bei Gruppierung
+// stehen gleiche Status zusammen (in der Reihenfolge von statuses).
+func (m *model) sortPicks(opts []fileOption) {
+	if m.sortCol == sortDefault && !m.groupByStatus {
+		return
+	}
+	key := func(o fileOption) string {
+		d := m.searchDocs[o.Path]
+		switch m.sortCol {
+		case sortTitle:
+			return strings.ToLower(d.Title)
+		case sortStatus:
+			return d.Status
+		case sortCreated:
+			return d.CreatedDate
+		case sortEdited:
+			return d.LastEditedAt
+		case sortTags:
+			return strings.ToLower(d.Tags)
+		}
+		return ""
+	}
+	less := func(a, b fileOption) bool {
+		switch m.sortCol {
+		case sortNo:
+			return lessADR(a, b)
+		case sortStatus:
+			return statusRank(key(a)) < statusRank(key(b))
+		}
+		return key(a) < key(b)
+	}
+	sort.SliceStable(opts, func(i, j int) bool {
+		if m.groupByStatus {
+			gi := statusRank(m.searchDocs[opts[i].Path].Status)
+			gj := statusRank(m.searchDocs[opts[j].Path].Status)
+			if gi != gj {
+				return gi < gj
+			}
+		}
+		if m.sortCol == sortDefault {
+			return false
+		}
+		if m.sortDesc {
+			return less(opts[j], opts[i])
+		}
+		return less(opts[i], opts[j])
+	})
+}
+
+// statusRank liefert die Position in statuses; Unbekanntes kommt ans Ende.
+func statusRank(s string) int {
+	for i, st := range statuses {
+		if strings.EqualFold(s, st) {
+			return i
+		}
+	}
+	return len(statuses)
+}
+
+func (m model) sortInfo() string {
+	s := "Sortierung: " + sortColumnNames[m.sortCol]
+	if m.sortCol != sortDefault {
+		if m.sortDesc {
+			s += " ▼"
+		} else {
+			s += " ▲"
+		}
+	}
+	if m.groupByStatus {
+		s += " · gruppiert nach Status"
+	}
+	return s
+}
+
+// noColumnWidth: breit genug für die längste Nummer samt Namensraum.
+func (m model) noColumnWidth() int {
+	w := 5
+	for _, o := range m.allOptions {
+		if o.NS != "" {
+			w = max(w, lipgloss.Width(o.NS)+6)
+		}
+	}
+	return min(w, 24)
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSortCycleCoversEveryColumn(t *testing.T) {
+	m := newBlankModel()
+	for _, c := range m.pickColumns(200) {
+		if c.sort == sortDefault {
+			t.Errorf("column %s is not sortable", c.title)
+		}
+	}
+}
+
+func TestSortByTags(t *testing.T) {
+	m := newBlankModel()
+	m.searchDocs = map[string]searchDoc{
+		"a": {Tags: "zeta"},
+		"b": {Tags: "Alpha, netz"},
+		"c": {Tags: "mesh"},
+	}
+	opts := []fileOption{{Path: "a"}, {Path: "b"}, {Path: "c"}}
+	m.sortCol = sortTags
+	m.sortPicks(opts)
+	var got []string
+	for _, o := range opts {
+		got = append(got, o.Path)
+	}
+	if strings.Join(got, "") != "bca" {
+		t.Errorf("order = %v, want [b c a]", got)
+	}
+	row := m.renderTableRow(fileOption{Path: "c", No: 3}, optionStyle, 200)
+	if !strings.Contains(row, "mesh") {
+		t.Errorf("row without tags: %q", row)
+	}
+}
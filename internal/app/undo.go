@@ -0,0 +1,185 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ------------------------ Rückgängig / Wiederholen ------------------------ */
+
+const (
+	undoMax         = 200
+	undoGroupWindow = 1500 * time.Millisecond // Tippen im selben Feld zusammenfassen
+	undoHelp        = " · CTRL+Z/Y rückgängig/wiederholen"
+)
+
+// editState ist ein vollständiger Stand aller Assistenten-Felder. Listen
+// werden roh gespeichert (inkl. leerer Punkte), damit auch Hinzufügen,
+// Löschen und Verschieben von Punkten umkehrbar sind.
+type editState struct {
+	Step         int      `json:"step"`
+	Title        string   `json:"title"`
+	StatusIdx    int      `json:"status_idx"`
+	Kontext      string   `json:"kontext"`
+	Entscheidung []string `json:"entscheidung"`
+	Konsequenzen []string `json:"konsequenzen"`
+	Alternativen []string `json:"alternativen"`
+	ListIdx      [3]int   `json:"list_idx"`
+	Beteiligte   string   `json:"beteiligte"`
+	Tags         string   `json:"tags"`
+}
+
+// sameContent vergleicht ohne Schritt und Listenposition.
+func (a editState) sameContent(b editState) bool {
+	return a.Title == b.Title && a.StatusIdx == b.StatusIdx && a.Kontext == b.Kontext &&
+		slices.Equal(a.Entscheidung, b.Entscheidung) && slices.Equal(a.Konsequenzen, b.Konsequenzen) &&
+		slices.Equal(a.Alternativen, b.Alternativen) && a.Beteiligte == b.Beteiligte && a.Tags == b.Tags
+}
+
+// normalized entspricht dem, was im Entwurf landet (leere Punkte entfallen).
+func (a editState) normalized() editState {
+	norm := func(items []string) []string {
+		lf := listField{}
+		lf.SetFromSlice(items, 1, 10)
+		return lf.Values()
+	}
+	a.Entscheidung, a.Konsequenzen, a.Alternativen = norm(a.Entscheidung), norm(a.Konsequenzen), norm(a.Alternativen)
+	return a
+}
+
+func (m model) editState() editState {
+	return editState{
+		Step:         m.step,
+		Title:        m.title.Value(),
+		StatusIdx:    m.statusIdx,
+		Kontext:      m.kontext.Value(),
+		Entscheidung: m.entscheidung.rawValues(),
+		Konsequenzen: m.konsequenzen.rawValues(),
+		Alternativen: m.alternativen.rawValues(),
+		ListIdx:      [3]int{m.entscheidung.idx, m.konsequenzen.idx, m.alternativen.idx},
+		Beteiligte:   m.beteiligte.Value(),
+		Tags:         m.tags.Value(),
+	}
+}
+
+func (m *model) applyEditState(s editState) {
+	w := m.kontext.Width()
+	m.title.SetValue(s.Title)
+	m.statusIdx = s.StatusIdx
+	m.kontext.SetValue(s.Kontext)
+	for i, lf := range []*listField{&m.entscheidung, &m.konsequenzen, &m.alternativen} {
+		lf.SetFromSlice([][]string{s.Entscheidung, s.Konsequenzen, s.Alternativen}[i], 5, w)
+		lf.idx = min(max(0, s.ListIdx[i]), len(lf.items)-1)
+	}
+	m.beteiligte.SetValue(s.Beteiligte)
+	m.tags.SetValue(s.Tags)
+	m.step = s.Step
+}
+
+// undoable: nur im Editor, nicht in Merge-, Historien- oder Lese-Ansicht.
+func (m model) undoable() bool {
+	return !m.startup && !m.readOnly && m.merge == nil && m.snaps == nil && !m.saving
+}
+
+// trackUndo legt den Stand vor einer Änderung auf den Stapel. Tippen im
+// selben Feld wird zusammengefasst, strukturelle Änderungen (Punkt löschen,
+// Status wechseln …) bekommen immer einen eigenen Eintrag.
+func (m *model) trackUndo(before editState, key tea.KeyMsg) {
+	if before.sameContent(m.editState()) {
+		return
+	}
+	group := fmt.Sprintf("%d/%v", before.Step, before.ListIdx)
+	typing := key.Type == tea.KeyRunes || key.Type == tea.KeyBackspace || key.Type == tea.KeyDelete
+	if !typing || group != m.undoGroup || time.Since(m.undoAt) > undoGroupWindow || len(m.undoStack) == 0 {
+		m.undoStack = append(m.undoStack, before)
+		if len(m.undoStack) > undoMax {
+			m.undoStack = m.undoStack[len(m.undoStack)-undoMax:]
+		}
+	}
+	m.redoStack = nil
+	m.undoGroup = group
+	if !typing {
+		m.undoGroup = ""
+	}
+	m.undoAt = time.Now()
+}
+
+func (m model) undo() (model, tea.Cmd) {
+	if len(m.undoStack) == 0 {
+		return m, nil
+	}
+	prev := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+	m.redoStack = append(m.redoStack, m.editState())
+	m.applyEditState(prev)
+	m.undoGroup = ""
+	return m, m.focusForStep()
+}
+
+func (m model) redo() (model, tea.Cmd) {
+	if len(m.redoStack) == 0 {
+		return m, nil
+	}
+	next := m.redoStack[len(m.redoStack)-1]
+	m.redoStack = m.redoStack[:len(m.redoStack)-1]
+	m.undoStack = append(m.undoStack, m.editState())
+	m.applyEditState(next)
+	m.undoGroup = ""
+	return m, m.focusForStep()
+}
+
+/* ------------------------- Persistenz neben dem Entwurf ------------------- */
+
+type undoFile struct {
+	Current editState   `json:"current"`
+	Undo    []editState `json:"undo"`
+	Redo    []editState `json:"redo,omitempty"`
+}
+
+func undoPath(draftPath string) string {
+	return strings.TrimSuffix(draftPath, ".json") + ".undo.json"
+}
+
+func (m model) toUndoFile() *undoFile {
+	if len(m.undoStack) == 0 && len(m.redoStack) == 0 {
+		return nil
+	}
+	return &undoFile{Current: m.editState(), Undo: m.undoStack, Redo: m.redoStack}
+}
+
+func writeUndo(draftPath string, uf *undoFile) error {
+	if uf == nil {
+		return nil
+	}
+	b, err := json.Marshal(uf)
+	if err != nil {
+		return err
+	}
+	return atomicWrite(undoPath(draftPath), b, 0o644)
+}
+
+// loadUndo übernimmt die gespeicherten Stapel nur, wenn sie zum geladenen
+// Stand passen – sonst wurde der Entwurf inzwischen anderweitig geändert.
+func (m *model) loadUndo() {
+	b, err := os.ReadFile(undoPath(m.draftPath()))
+	if err != nil {
+		return
+	}
+	var uf undoFile
+	if json.Unmarshal(b, &uf) != nil || !uf.Current.normalized().sameContent(m.editState().normalized()) {
+		return
+	}
+	m.undoStack, m.redoStack = uf.Undo, uf.Redo
+}
+
+// removeDraftSidecars entfernt Historie und Rückgängig-Stapel eines Entwurfs.
+func removeDraftSidecars(draftPath string) {
+	removeSnapshots(draftPath)
+	_ = os.Remove(undoPath(draftPath))
+}
@@ -0,0 +1,497 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/* ------------- Prüfregeln: Entscheidungen gegen den Code prüfen ----------- */
+
+// rulesHeading ist der Abschnitt, in dem ein ADR seine Regeln nennt, je
+// Zeile eine: "- import-verboten: github.com/pkg/errors in internal/**".
+const rulesHeading = "Prüfregeln"
+
+const (
+	ruleForbidImport  = "import-verboten"
+	ruleRequireImport = "import-pflicht"
+	ruleRequireFile   = "datei-pflicht"
+	ruleForbidFile    = "datei-verboten"
+	ruleForbidRegex   = "regex-verboten"
+)
+
+var ruleAliases = map[string]string{
+	"forbid-import":  ruleForbidImport,
+	"require-import": ruleRequireImport,
+	"require-file":   ruleRequireFile,
+	"forbid-file":    ruleForbidFile,
+	"forbid-regex":   ruleForbidRegex,
+}
+
+const verifyMaxShown = 10 // Verstöße je Regel in der Textausgabe
+
+type fitnessRule struct {
+	Kind  string
+	Arg   string // Importpfad, Glob oder regulärer Ausdruck
+	Scope string // Glob für die geprüften Dateien, leer = alle passenden
+	Text  string // Zeile wie im ADR
+}
+
+var ruleLineRe = regexp.MustCompile(`^[-*]\s+([\w-]+)\s*:\s*(.+)$`)
+
+// parseRules liest den Abschnitt "Prüfregeln". Zeilen, die keine Regel sind
+// (Fließtext, Leerzeilen), werden übergangen; Aufzählungspunkte mit
+// unbekannter Art oder fehlendem Argument landen in errs.
+func parseRules(section string) (rules []fitnessRule, errs []string) {
+	for _, line := range strings.Split(section, "\n") {
+		line = strings.TrimSpace(line)
+		mm := ruleLineRe.FindStringSubmatch(line)
+		text := strings.TrimSpace(strings.TrimLeft(line, "-*"))
+		if mm == nil {
+			if text != line {
+				errs = append(errs, text+" – erwartet „art: argument [in glob]“")
+			}
+			continue
+		}
+		kind := strings.ToLower(mm[1])
+		if k, ok := ruleAliases[kind]; ok {
+			kind = k
+		}
+		switch kind {
+		case ruleForbidImport, ruleRequireImport, ruleRequireFile, ruleForbidFile, ruleForbidRegex:
+		default:
+			errs = append(errs, text+" – unbekannte Art „"+mm[1]+"“")
+			continue
+		}
+		arg, rest := ruleWord(mm[2])
+		r := fitnessRule{Kind: kind, Arg: arg, Text: text}
+		if scope, ok := strings.CutPrefix(rest, "in "); ok {
+			r.Scope, rest = ruleWord(scope)
+		}
+		switch {
+		case r.Arg == "":
+			errs = append(errs, text+" – Argument fehlt")
+		case rest != "":
+			errs = append(errs, text+" – unerwartet: „"+rest+"“")
+		default:
+			rules = append(rules, r)
+		}
+	}
+	return rules, errs
+}
+
+// ruleWord trennt das erste Wort ab; in Backticks darf es Leerzeichen
+// enthalten (für reguläre Ausdrücke).
+func ruleWord(s string) (word, rest string) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "`") {
+		if i := strings.Index(s[1:], "`"); i >= 0 {
+			return s[1 : i+1], strings.TrimSpace(s[i+2:])
+		}
+	}
+	word, rest, _ = strings.Cut(s, " ")
+	return word, strings.TrimSpace(rest)
+}
+
+// globRe übersetzt einen Glob in einen regulären Ausdruck: "**" passt auf
+// beliebig viele Verzeichnisse, "*" und "?" nicht über "/" hinweg.
+func globRe(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// repoTree ist der Dateibestand, gegen den geprüft wird; Importe und
+// Globs werden nur einmal ausgewertet.
+type repoTree struct {
+	base    string
+	files   []string // relativ zur Repo-Wurzel, mit "/"
+	all     []string // wie files, aber auch versteckte und skipDirs
+	imports map[string][]goImport
+	globs   map[string]*regexp.Regexp
+}
+
+type goImport struct {
+	Path string
+	Line int
+}
+
+func loadRepoTree(base string) *repoTree {
+	t := &repoTree{base: base, imports: map[string][]goImport{}, globs: map[string]*regexp.Regexp{}}
+	walkRepo(base, func(_, rel string, _ fs.DirEntry) {
+		t.files = append(t.files, filepath.ToSlash(rel))
+	})
+	sort.Strings(t.files)
+	return t
+}
+
+// match: ohne "/" im Muster zählt nur der Dateiname (wie bei .gitignore).
+func (t *repoTree) match(glob, file string) (bool, error) {
+	re, ok := t.globs[glob]
+	if !ok {
+		var err error
+		if re, err = globRe(glob); err != nil {
+			return false, err
+		}
+		t.globs[glob] = re
+	}
+	if !strings.Contains(glob, "/") {
+		file = path.Base(file)
+	}
+	return re.MatchString(file), nil
+}
+
+// allFiles liefert für datei-pflicht/datei-verboten den ganzen Bestand –
+// auch vendor/ oder .github/, nur .git und das Meta-Verzeichnis nicht.
+func (t *repoTree) allFiles() []string {
+	if t.all != nil {
+		return t.all
+	}
+	t.all = []string{}
+	_ = filepath.WalkDir(t.base, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if p != t.base && (d.Name() == ".git" || d.Name() == metaDir) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if rel, err := filepath.Rel(t.base, p); err == nil {
+			t.all = append(t.all, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(t.all)
+	return t.all
+}
+
+// inScope liefert die Dateien, auf die scope passt; ohne scope alle, die
+// keep gelten lässt.
+func (t *repoTree) inScope(scope string, keep func(string) bool) ([]string, error) {
+	return t.filter(t.files, scope, keep)
+}
+
+func (t *repoTree) filter(files []string, scope string, keep func(string) bool) ([]string, error) {
+	var out []string
+	for _, f := range files {
+		if !keep(f) {
+			continue
+		}
+		if scope != "" {
+			ok, err := t.match(scope, f)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+func (t *repoTree) goImports(file string) []goImport {
+	if imps, ok := t.imports[file]; ok {
+		return imps
+	}
+	var imps []goImport
+	fset := token.NewFileSet()
+	if f, err := parser.ParseFile(fset, file, nil, parser.ImportsOnly); err == nil {
+		for _, is := range f.Imports {
+			p, _ := strconv.Unquote(is.Path.Value)
+			imps = append(imps, goImport{Path: p, Line: fset.Position(is.Pos()).Line})
+		}
+	}
+	t.imports[file] = imps
+	return imps
+}
+
+func importMatches(imp, want string) bool {
+	return imp == want || strings.HasPrefix(imp, want+"/")
+}
+
+func isGoFile(f string) bool { return strings.HasSuffix(f, ".go") }
+
+// check liefert die Verstöße gegen r.
+func (t *repoTree) check(r fitnessRule) ([]string, error) {
+	var out []string
+	switch r.Kind {
+	case ruleForbidImport:
+		files, err := t.inScope(r.Scope, isGoFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			for _, imp := range t.goImports(f) {
+				if importMatches(imp.Path, r.Arg) {
+					out = append(out, fmt.Sprintf("%s:%d importiert %s", f, imp.Line, imp.Path))
+				}
+			}
+		}
+
+	case ruleRequireImport:
+		files, err := t.inScope(r.Scope, func(f string) bool { return isGoFile(f) && !strings.HasSuffix(f, "_test.go") })
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return []string{"keine Go-Dateien im Bereich " + orDash(r.Scope)}, nil
+		}
+		found := map[string]bool{}
+		var pkgs []string
+		for _, f := range files {
+			dir := path.Dir(f)
+			if _, seen := found[dir]; !seen {
+				found[dir] = false
+				pkgs = append(pkgs, dir)
+			}
+			for _, imp := range t.goImports(f) {
+				if importMatches(imp.Path, r.Arg) {
+					found[dir] = true
+				}
+			}
+		}
+		for _, p := range pkgs {
+			if !found[p] {
+				out = append(out, p+": kein Import von "+r.Arg)
+			}
+		}
+
+	case ruleRequireFile, ruleForbidFile:
+		files, err := t.filter(t.allFiles(), r.Arg, func(string) bool { return true })
+		if err != nil {
+			return nil, err
+		}
+		if r.Kind == ruleForbidFile {
+			return files, nil
+		}
+		if len(files) == 0 {
+			out = append(out, "keine Datei passt auf "+r.Arg)
+		}
+
+	case ruleForbidRegex:
+		re, err := regexp.Compile(r.Arg)
+		if err != nil {
+			return nil, err
+		}
+		keep := func(f string) bool { return codeExts[strings.ToLower(path.Ext(f))] }
+		if r.Scope != "" {
+			keep = func(string) bool { return true }
+		}
+		files, err := t.inScope(r.Scope, keep)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			out = append(out, grepFile(f, re)...)
+		}
+	}
+	return out, nil
+}
+
+// grepFile liefert die Zeilen von file, auf die re passt.
+func grepFile(file string, re *regexp.Regexp) []string {
+	fh, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer fh.Close()
+	if fi, err := fh.Stat(); err != nil || fi.Size() > codeRefMaxSize {
+		return nil
+	}
+	var out []string
+	sc := bufio.NewScanner(fh)
+	sc.Buffer(make([]byte, 64*1024), codeRefMaxSize)
+	for n := 1; sc.Scan(); n++ {
+		if re.MatchString(sc.Text()) {
+			out = append(out, fmt.Sprintf("%s:%d: %s", file, n, strings.TrimSpace(sc.Text())))
+		}
+	}
+	return out
+}
+
+/* ---------------------------- adronaut verify ----------------------------- */
+
+type ruleResult struct {
+	Rule       string   `json:"rule"`
+	Violations []string `json:"violations,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+func (r ruleResult) failed() bool { return len(r.Violations) > 0 || r.Error != "" }
+
+type verifyEntry struct {
+	ID      string       `json:"id"`
+	Path    string       `json:"path"`
+	Title   string       `json:"title"`
+	Status  string       `json:"status"`
+	Results []ruleResult `json:"results"`
+}
+
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	all := fs.Bool("all", false, "auch vorgeschlagene ADRs prüfen (Standard: nur angenommene)")
+	format := fs.String("format", "text", "Ausgabeformat: text oder json")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Benutzung: adronaut verify [adr …] [--all] [--format text|json]")
+		fs.PrintDefaults()
+	}
+	only, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("--format: unbekanntes Format %q (text, json)", *format)
+	}
+
+	tree := loadRepoTree(".")
+	var entries []verifyEntry
+	named := map[string]bool{}
+	for _, o := range scanAllADRFiles() {
+		if len(only) > 0 && !matchesAny(o, only, named) {
+			continue
+		}
+		b, err := os.ReadFile(o.Path)
+		if err != nil {
+			return err
+		}
+		pa := parseADRText(o.Path, string(b))
+		if strings.TrimSpace(pa.Pruefregeln) == "" {
+			continue
+		}
+		if len(only) == 0 && !enforced(pa.Status, *all) {
+			continue
+		}
+		e := verifyEntry{ID: adrID(o.NS, o.No), Path: o.Path, Title: pa.Title, Status: pa.Status}
+		rules, errs := parseRules(pa.Pruefregeln)
+		for _, msg := range errs {
+			e.Results = append(e.Results, ruleResult{Rule: msg, Error: "Regel nicht lesbar"})
+		}
+		for _, r := range rules {
+			res := ruleResult{Rule: r.Text}
+			v, err := tree.check(r)
+			if err != nil {
+				res.Error = err.Error()
+			}
+			res.Violations = v
+			e.Results = append(e.Results, res)
+		}
+		entries = append(entries, e)
+	}
+	for _, a := range only {
+		if !named[a] {
+			return fmt.Errorf("kein ADR %q gefunden", a)
+		}
+	}
+
+	failed := 0
+	for _, e := range entries {
+		for _, r := range e.Results {
+			if r.failed() {
+				failed++
+			}
+		}
+	}
+	if *format == "json" {
+		if entries == nil {
+			entries = []verifyEntry{}
+		}
+		b, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	} else {
+		printVerify(entries, failed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d Prüfregel(n) verletzt", failed)
+	}
+	return nil
+}
+
+// enforced: angenommene Entscheidungen gelten, mit all auch vorgeschlagene.
+func enforced(status string, all bool) bool {
+	s := strings.TrimSpace(status)
+	return strings.EqualFold(s, "Angenommen") || all && strings.EqualFold(s, "Vorgeschlagen")
+}
+
+func matchesAny(o fileOption, args []string, named map[string]bool) bool {
+	hit := false
+	for _, a := range args {
+		if matchesADR(o, a) {
+			named[a] = true
+			hit = true
+		}
+	}
+	return hit
+}
+
+func printVerify(entries []verifyEntry, failed int) {
+	if len(entries) == 0 {
+		fmt.Println("Keine angenommenen ADRs mit Prüfregeln.")
+		return
+	}
+	rules := 0
+	for _, e := range entries {
+		fmt.Println(labelStyle.Render(e.ID) + " " + e.Title + helpStyle.Render(" ("+orDash(e.Status)+")"))
+		for _, r := range e.Results {
+			rules++
+			if !r.failed() {
+				fmt.Println("  " + okStyle.Render("✔") + " " + r.Rule)
+				continue
+			}
+			fmt.Println("  " + errorStyle.Render("✘") + " " + r.Rule)
+			if r.Error != "" {
+				fmt.Println("      " + errorStyle.Render(r.Error))
+			}
+			for i, v := range r.Violations {
+				if i == verifyMaxShown {
+					fmt.Println(helpStyle.Render(fmt.Sprintf("      … und %d weitere", len(r.Violations)-i)))
+					break
+				}
+				fmt.Println("      " + v)
+			}
+		}
+	}
+	summary := fmt.Sprintf("%d Regel(n) in %d ADR(s) geprüft", rules, len(entries))
+	if failed == 0 {
+		fmt.Println(okStyle.Render("✔ " + summary + ", alle erfüllt"))
+		return
+	}
+	fmt.Println(errorStyle.Render(fmt.Sprintf("✘ %s, %d verletzt", summary, failed)))
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Dateiregeln sehen auch vendor/ und versteckte Verzeichnisse, nur .git und
+// das Meta-Verzeichnis nicht.
+func TestFileRulesSeeWholeTree(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for _, f := range []string{"vendor/x/x.go", ".github/CODEOWNERS", ".git/config", metaDir + "/locks/a.lock", "main.go"} {
+		if err := os.MkdirAll(filepath.Dir(f), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(f, []byte("package x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rules, errs := parseRules("- datei-verboten: vendor/**\n- datei-pflicht: .github/CODEOWNERS\n" +
+		"- datei-verboten: .git/**\n- datei-verboten: *.lock")
+	if len(errs) > 0 || len(rules) != 4 {
+		t.Fatalf("parseRules = %v, %v", rules, errs)
+	}
+	tree := loadRepoTree(".")
+	want := [][]string{{"vendor/x/x.go"}, nil, nil, nil}
+	for i, r := range rules {
+		got, err := tree.check(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(want[i]) || (len(got) > 0 && got[0] != want[i][0]) {
+			t.Errorf("%s: got %v, want %v", r.Text, got, want[i])
+		}
+	}
+}
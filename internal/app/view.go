@@ -7,83 +7,211 @@ import (
 	"strings"
 )
 
+var stepNames = []string{
+	"Titel", "Status", "Kontext", "Entscheidung",
+	"Konsequenzen", "Alternativen", "Beteiligte", "Tags", "Speichern",
+}
+
 func (m model) header() string {
 	prefix := "ADRonaut"
 	if m.editingPath != "" {
 		prefix += " – Bearbeite: " + filepath.Base(m.editingPath)
 	}
-	steps := []string{
-		"Titel", "Status", "Kontext", "Entscheidung",
-		"Konsequenzen", "Alternativen", "Beteiligte", "Tags", "Speichern",
+	switch {
+	case m.viewRepo != "":
+		prefix += " – " + m.viewRepo + " (nur lesen)"
+	case m.readOnly:
+		prefix += " – historischer Stand " + shortRev(m.viewRev) + " (nur lesen)"
+	}
+	titleLine := titleStyle.Render(prefix)
+	if m.form {
+		return lipgloss.JoinVertical(lipgloss.Left, titleLine, helpStyle.Render("Formular · F3 Schritt für Schritt")) + "\n" + m.extErrLine()
 	}
-	parts := make([]string, len(steps))
-	for i, s := range steps {
+	parts := make([]string, len(stepNames))
+	for i, s := range stepNames {
 		if i == m.step {
 			parts[i] = activeStyle.Render(s)
 		} else {
 			parts[i] = s
 		}
 	}
-	titleLine := titleStyle.Render(prefix)
 	menuLine := strings.Join(parts, "  ·  ")
-	return lipgloss.JoinVertical(lipgloss.Left, titleLine, menuLine) + "\n"
+	return lipgloss.JoinVertical(lipgloss.Left, titleLine, menuLine) + "\n" + m.extErrLine()
+}
+
+// extErrLine: Rückmeldung nach ALT+E, bis zur nächsten Taste.
+func (m model) extErrLine() string {
+	if m.extErr == nil {
+		return ""
+	}
+	msg := m.extErr.Error()
+	if m.extDraft != "" {
+		msg += " – ALT+SHIFT+E öffnet deinen Text erneut"
+	}
+	return errorStyle.Render("Nicht übernommen: ") + msg + "\n\n"
 }
 
 func (m model) help(keys string) string { return helpStyle.Render(keys) }
 
 func (m model) viewPicker() string {
+	if m.drafts != nil {
+		return lipgloss.NewStyle().Padding(0, framePadding).Render(m.viewDrafts())
+	}
 	var b strings.Builder
-	b.WriteString(titleStyle.Render("ADRonaut – Datei auswählen oder neuen ADR anlegen"))
-	b.WriteString("\n\n")
-
-	// Suchfeld
-	b.WriteString(m.filter.View())
-	b.WriteString("\n\n")
+	b.WriteString(m.pickerHead())
 
-	hasDraft := false
-	// (Rest unverändert …)
+	// Liste rendern – nur das sichtbare Fenster ab pickOffset
+	pw := m.previewWidth()
+	lw := 0
+	if m.width > 0 {
+		lw = m.width - 2*framePadding - pw
+	}
+	h := m.pickListHeight()
 
-	if len(m.pickOptions) == 1 { // nur "Neuer ADR"
-		b.WriteString("(Keine ADRs im aktuellen Verzeichnis gefunden)\n\n")
+	// Badges stehen rechts neben der Tabelle; die Tabelle schrumpft um die
+	// breiteste Badge-Spalte im sichtbaren Fenster.
+	badges := map[string]string{}
+	bw := 0
+	for i := m.pickOffset; i < len(m.pickOptions) && i < m.pickOffset+h; i++ {
+		opt := m.pickOptions[i]
+		s := ""
+		if opt.Repo != "" {
+			s += " " + chip(opt.Repo)
+		}
+		if opt.Rev != "" {
+			s += " " + chip(historicBadge)
+		}
+		if li, ok := m.locks[lockKey(opt)]; ok && opt.Path != newAdrSentinel && opt.Rev == "" && !li.same(m.lock) {
+			s += " " + lockChip(li)
+		}
+		for _, bb := range m.hitBadges[opt.Path] {
+			s += " " + chipWithCount(bb)
+		}
+		badges[opt.Path] = s
+		bw = max(bw, lipgloss.Width(s))
 	}
-	if hasDraft {
-		b.WriteString(helpStyle.Render("Es liegen unveröffentlichte Entwürfe vor – du kannst sie wiederherstellen.") + "\n\n")
+	tw := lw - 2
+	if lw > 0 {
+		tw = max(40, lw-2-bw)
 	}
 
-	// Liste rendern
-	for i, opt := range m.pickOptions {
+	rows := 0
+	var list strings.Builder
+	list.WriteString("  " + m.renderTableHeader(tw) + "\n")
+	for i := m.pickOffset; i < len(m.pickOptions) && rows < h; i++ {
+		opt := m.pickOptions[i]
 		st := optionStyle
 		isSel := (!m.filter.Focused() && i == m.pickIdx)
 		if isSel {
 			st = selectedStyle
 		}
 
-		line := st.Render(opt.Label)
-
-		// Badges anhängen
-		if bs := m.hitBadges[opt.Path]; len(bs) > 0 {
-			for _, bb := range bs {
-				line += " " + chipWithCount(bb)
-			}
+		mark := "  "
+		if m.selected[opt.Path] {
+			mark = okStyle.Render("● ")
 		}
-		b.WriteString(line + "\n")
+		line := mark + m.renderTableRow(opt, st, tw) + badges[opt.Path]
+		list.WriteString(truncateLine(line, lw) + "\n")
+		rows++
 
 		// Snippet nur für die aktuelle Auswahl zeigen (gegen Clutter)
 		if isSel {
 			if sn := strings.TrimSpace(m.hitSnippet[opt.Path]); sn != "" {
-				b.WriteString("  " + sn + "\n")
+				list.WriteString(truncateLine("  "+sn, lw) + "\n")
+				rows++
 			}
 		}
 	}
 
+	if pw > 0 {
+		left := lipgloss.NewStyle().Width(lw).Render(strings.TrimRight(list.String(), "\n"))
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, m.renderPreview(pw, h+1)))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(list.String())
+	}
+
+	b.WriteString(m.pickerFoot())
+
+	return lipgloss.NewStyle().Padding(0, framePadding).Render(b.String())
+}
+
+// pickerHead ist alles über der Tabelle: Titel, Suchfeld und Hinweise.
+func (m model) pickerHead() string {
+	var b strings.Builder
+	if m.catalog {
+		b.WriteString(titleStyle.Render("ADRonaut – Katalog aller Repositorys"))
+	} else {
+		b.WriteString(titleStyle.Render("ADRonaut – Datei auswählen oder neuen ADR anlegen"))
+	}
+	b.WriteString("\n\n")
+
+	// Suchfeld
+	b.WriteString(m.filter.View())
+	b.WriteString("   " + helpStyle.Render(m.sortInfo()))
+	b.WriteString("\n\n")
+
+	if len(m.pickOptions) == 1 && !m.catalog { // nur "Neuer ADR"
+		b.WriteString("(Keine ADRs im aktuellen Verzeichnis gefunden)\n\n")
+	}
+	if m.historyLoading {
+		b.WriteString(helpStyle.Render("Lade gelöschte und umbenannte ADRs aus der Git-Historie …") + "\n\n")
+	}
+	if m.err != nil {
+		b.WriteString(errorStyle.Render("Fehler: ") + m.err.Error() + "\n\n")
+	}
+	if m.notice != "" {
+		b.WriteString(okStyle.Render(m.notice) + "\n\n")
+	}
+
+	return m.wrapPicker(b.String())
+}
+
+// pickerFoot ist die Schnellaktion bzw. die Tastenhilfe unter der Liste.
+func (m model) pickerFoot() string {
+	if m.quick != quickNone {
+		return m.wrapPicker("\n" + m.viewQuick() + "\n")
+	}
+
 	// Kontextsensitive Hilfe
-	helpText := "TAB oder ↑/↓ wählen · SHIFT+Tab zurück zur Suche · ENTER öffnen · ESC/STRG+C beenden"
+	common := "ALT+O/R/G sortieren/umkehren/gruppieren · ALT+H Historie · ESC beenden"
+	helpText := "TAB oder ↑/↓ wählen · BILD↑/↓ POS1/ENDE · SHIFT+Tab Suche · ENTER öffnen · " + common +
+		"\nSPACE/ALT+M markieren · ALT+S Status · ALT+T Tags · ALT+N/B Tag/Beteiligte umbenennen · ALT+D Kopie · ALT+A Archiv · ALT+X Entwurf verwerfen · ALT+E Entwürfe"
 	if m.filter.Focused() {
-		helpText = "TAB zur Liste · ENTER öffnen · ESC/STRG+C beenden"
+		helpText = "TAB zur Liste · ENTER öffnen · " + common
+	}
+	if m.catalog {
+		helpText = "TAB oder ↑/↓ wählen · SHIFT+Tab Suche · ENTER ansehen (nur lesen) · ALT+O/R/G sortieren/umkehren/gruppieren · ESC beenden"
+		if len(m.pickOptions) > 0 {
+			helpText = fmt.Sprintf("%d/%d · %s", m.pickIdx+1, len(m.pickOptions), helpText)
+		}
+	} else if n := len(m.pickOptions) - 1; n > 0 {
+		helpText = fmt.Sprintf("%d/%d · %s", max(m.pickIdx, 1), n, helpText)
 	}
-	b.WriteString("\n" + m.help(helpText))
+	return m.wrapPicker("\n" + m.help(helpText))
 
-	return lipgloss.NewStyle().Padding(0, framePadding).Render(b.String())
+}
+
+// wrapPicker bricht auf die Fensterbreite um, damit pickListHeight die
+// Zeilen so zählt, wie das Terminal sie zeigt.
+func (m model) wrapPicker(s string) string {
+	if m.width <= 0 {
+		return s
+	}
+	lines := strings.Split(lipgloss.NewStyle().Width(m.width-2*framePadding).Render(s), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncateLine kürzt eine (ggf. gestylte) Zeile auf w Zellen; w <= 0 heißt
+// unbegrenzt.
+func truncateLine(s string, w int) string {
+	if w <= 0 {
+		return s
+	}
+	return lipgloss.NewStyle().MaxWidth(w).Render(s)
 }
 
 func (m model) View() string {
@@ -94,11 +222,21 @@ func (m model) View() string {
 	var b strings.Builder
 	b.WriteString(m.header())
 
+	if m.snaps != nil {
+		b.WriteString(m.viewSnapshots())
+		return lipgloss.NewStyle().Padding(0, framePadding).Render(b.String())
+	}
+
+	if m.form && m.step < 8 {
+		b.WriteString(m.viewForm())
+		return lipgloss.NewStyle().Padding(0, framePadding).Render(b.String())
+	}
+
 	switch m.step {
 	case 0:
 		b.WriteString(labelStyle.Render("Titel") + "\n")
 		b.WriteString(m.title.View())
-		b.WriteString("\n\n" + m.help("TAB weiter · SHIFT+TAB zurück · ENTER weiter · ESC/STRG+C abbrechen"))
+		b.WriteString("\n\n" + m.help("TAB weiter · SHIFT+TAB zurück · ENTER weiter · F2 frühere Stände · ESC/STRG+C abbrechen"+undoHelp+editorHelp))
 	case 1:
 		b.WriteString(labelStyle.Render("Status") + "\n")
 		for i, s := range statuses {
@@ -111,59 +249,52 @@ func (m model) View() string {
 				b.WriteString("   ")
 			}
 		}
-		b.WriteString("\n\n" + m.help("CTRL+N/CTRL+P wählen · ENTER/SPACE bestätigen · TAB weiter · SHIFT+TAB zurück"))
+		b.WriteString("\n\n" + m.help("CTRL+N/CTRL+P wählen · ENTER/SPACE bestätigen · TAB weiter · SHIFT+TAB zurück"+undoHelp+editorHelp))
 	case 2:
 		b.WriteString(labelStyle.Render("Kontext") + "\n")
 		b.WriteString(m.kontext.View())
-		b.WriteString("\n\n" + m.help("TAB weiter · SHIFT+TAB zurück"))
+		b.WriteString("\n\n" + m.help("TAB weiter · SHIFT+TAB zurück"+undoHelp+editorHelp))
 	case 3:
 		b.WriteString(labelStyle.Render("Entscheidung"))
 		b.WriteString(fmt.Sprintf("  (%d/%d)\n", m.entscheidung.idx+1, len(m.entscheidung.items)))
 		b.WriteString(m.entscheidung.current().View())
-		b.WriteString("\n\n" + m.help("CTRL+O neuer Punkt · CTRL+G nächster Punkt · CTRL+X Punkt löschen · TAB weiter · SHIFT+TAB zurück"))
+		b.WriteString("\n\n" + m.help("CTRL+O neuer Punkt · CTRL+G nächster Punkt · CTRL+X Punkt löschen · ALT+↑/↓ verschieben · TAB weiter · SHIFT+TAB zurück"+undoHelp+editorHelp))
 	case 4:
 		b.WriteString(labelStyle.Render("Konsequenzen"))
 		b.WriteString(fmt.Sprintf("  (%d/%d)\n", m.konsequenzen.idx+1, len(m.konsequenzen.items)))
 		b.WriteString(m.konsequenzen.current().View())
-		b.WriteString("\n\n" + m.help("CTRL+O neuer Punkt · CTRL+G nächster Punkt · CTRL+X Punkt löschen · TAB weiter · SHIFT+TAB zurück"))
+		b.WriteString("\n\n" + m.help("CTRL+O neuer Punkt · CTRL+G nächster Punkt · CTRL+X Punkt löschen · ALT+↑/↓ verschieben · TAB weiter · SHIFT+TAB zurück"+undoHelp+editorHelp))
 
 	case 5:
 		b.WriteString(labelStyle.Render("Alternativen"))
 		b.WriteString(fmt.Sprintf("  (%d/%d)\n", m.alternativen.idx+1, len(m.alternativen.items)))
 		b.WriteString(m.alternativen.current().View())
-		b.WriteString("\n\n" + m.help("CTRL+O neuer Punkt · CTRL+G nächster Punkt · CTRL+X Punkt löschen · TAB weiter · SHIFT+TAB zurück"))
+		b.WriteString("\n\n" + m.help("CTRL+O neuer Punkt · CTRL+G nächster Punkt · CTRL+X Punkt löschen · ALT+↑/↓ verschieben · TAB weiter · SHIFT+TAB zurück"+undoHelp+editorHelp))
 
 	case 6:
 		b.WriteString(labelStyle.Render("Beteiligte (Komma-getrennt)") + "\n")
 		b.WriteString(m.beteiligte.View())
-		b.WriteString("\n\n" + m.help("TAB weiter · SHIFT+TAB zurück · ENTER weiter"))
+		b.WriteString("\n\n" + m.help("TAB weiter · SHIFT+TAB zurück · ENTER weiter"+undoHelp+editorHelp))
 	case 7:
 		b.WriteString(labelStyle.Render("Tags (Komma-getrennt)") + "\n")
 		b.WriteString(m.tags.View())
-		b.WriteString("\n\n" + m.help("TAB weiter · SHIFT+TAB zurück · ENTER weiter"))
+		b.WriteString("\n\n" + m.help("TAB weiter · SHIFT+TAB zurück · ENTER weiter"+undoHelp+editorHelp))
 	case 8:
 		b.WriteString(labelStyle.Render("Speichern") + "\n")
-		preview := buildMarkdownPreview(m)
-
+		if m.merge != nil {
+			b.WriteString(m.viewMerge())
+			break
+		}
 		if m.saving {
 			b.WriteString(okStyle.Render("Speichere …"))
 		} else if m.err != nil {
 			b.WriteString(errorStyle.Render("Fehler: ") + m.err.Error())
 			b.WriteString("\n\n" + m.help("S erneut speichern · B/SHIFT+TAB zurück"))
-		} else if m.confirming {
-			sum, missing := m.buildSaveSummary()
-			b.WriteString("Vorschau (Kopf):\n")
-			b.WriteString(preview)
-			b.WriteString("\n\n" + labelStyle.Render("Überprüfung") + "\n")
-			b.WriteString(sum)
-			if len(missing) > 0 {
-				b.WriteString("\n" + errorStyle.Render("Fehlt/leer: "+strings.Join(missing, ", ")))
-			}
-			b.WriteString("\n\n" + m.help("J/ENTER speichern · N/B/ESC abbrechen"))
 		} else {
-			b.WriteString("Vorschau (Kopf):\n")
-			b.WriteString(preview)
-			b.WriteString("\n\n" + m.help("S Speicherdialog öffnen · B/SHIFT+TAB zurück · ESC/STRG+C abbrechen"))
+			vp := m.previewPane()
+			b.WriteString(m.previewTitle(vp) + "\n")
+			b.WriteString(vp.View() + "\n")
+			b.WriteString(m.saveFooter())
 		}
 	}
 
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ------------- Live-Reload des Pickers (mtime-Polling statt Watcher) ------ */
+
+const watchInterval = 2 * time.Second
+
+type fileStamp struct {
+	mod  time.Time
+	size int64
+}
+
+type watchTickMsg struct{}
+
+type filesChangedMsg struct {
+	stamps  map[string]fileStamp
+	changed []string // neu oder verändert
+	removed []string
+	locks   map[string]lockInfo
+}
+
+func scheduleWatch() tea.Cmd {
+	return tea.Tick(watchInterval, func(time.Time) tea.Msg { return watchTickMsg{} })
+}
+
+// snapshotFiles erfasst mtime und Größe aller ADRs und Entwürfe – mit
+// denselben Pfaden, die scanADRFiles/scanDrafts liefern.
+func snapshotFiles() map[string]fileStamp {
+	out := map[string]fileStamp{}
+	add := func(dir string, keep func(string) bool) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, e := range entries {
+			if e.IsDir() || !keep(e.Name()) {
+				continue
+			}
+			if fi, err := e.Info(); err == nil {
+				out[filepath.Join(dir, e.Name())] = fileStamp{mod: fi.ModTime(), size: fi.Size()}
+			}
+		}
+	}
+	for _, r := range adrRoots {
+		add(r.Dir, adrFileRe.MatchString)
+	}
+	add(autosaveDir, func(n string) bool { return strings.HasSuffix(n, ".draft.json") })
+	return out
+}
+
+// watchCmd vergleicht den aktuellen Stand mit old; ohne Änderung kommt ein
+// leeres filesChangedMsg zurück.
+func watchCmd(old map[string]fileStamp) tea.Cmd {
+	return func() tea.Msg {
+		cur := snapshotFiles()
+		msg := filesChangedMsg{stamps: cur, locks: readLocks()}
+		for p, st := range cur {
+			if o, ok := old[p]; !ok || !o.mod.Equal(st.mod) || o.size != st.size {
+				msg.changed = append(msg.changed, p)
+			}
+		}
+		for p := range old {
+			if _, ok := cur[p]; !ok {
+				msg.removed = append(msg.removed, p)
+			}
+		}
+		sort.Strings(msg.changed)
+		sort.Strings(msg.removed)
+		return msg
+	}
+}
+
+// applyFileChanges liest nur die betroffenen Dateien neu ein. Suchbegriff,
+// Auswahl, Markierungen und Badges bleiben erhalten. Sperren werden bei
+// jedem Durchlauf neu gelesen.
+func (m model) applyFileChanges(msg filesChangedMsg) model {
+	m.stamps = msg.stamps
+	m.locks = msg.locks
+	if len(msg.changed) == 0 && len(msg.removed) == 0 {
+		return m
+	}
+	m.refreshSaveDiff() // die Datei auf der Platte kann sich geändert haben
+	sel := ""
+	if m.pickIdx < len(m.pickOptions) {
+		sel = m.pickOptions[m.pickIdx].Path
+	}
+	for _, p := range msg.removed {
+		m.updateOption(p, "")
+		delete(m.selected, p)
+	}
+	for _, p := range msg.changed {
+		m.updateOption(p, p)
+	}
+	m.applyFilter(m.lastQuery)
+	m.selectPath(sel)
+	return m
+}
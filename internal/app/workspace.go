@@ -0,0 +1,347 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/* ------------------ Arbeitsbereich: Repo-Wurzel, Konfiguration ------------ */
+
+const configFile = ".adronaut.json"
+
+// config ist der Inhalt von .adronaut.json in der Repo-Wurzel.
+type config struct {
+	// Drafts: "state" (Standard) legt Entwürfe unter $XDG_STATE_HOME ab,
+	// "repo" wie früher in .adronaut/ im Repository.
+	Drafts string `json:"drafts,omitempty"`
+	// ADRDir: Verzeichnis der ADRs relativ zur Repo-Wurzel.
+	ADRDir string `json:"adr_dir,omitempty"`
+	// Roots: weitere ADR-Verzeichnisse je Namensraum (Monorepo); ohne
+	// Angabe werden sie gesucht.
+	Roots map[string]string `json:"roots,omitempty"`
+	// Numbering: "local" (Standard), "git" oder "timestamp".
+	Numbering string `json:"numbering,omitempty"`
+}
+
+// adrDirCandidates werden ohne Konfiguration der Reihe nach geprüft.
+var adrDirCandidates = []string{"docs/adr", "doc/adr", "docs/decisions", "docs/architecture/decisions", "adr", "decisions"}
+
+var (
+	// autosaveDir enthält Entwürfe samt Historie und Rückgängig-Stapel.
+	autosaveDir = ".adronaut"
+	// metaDir liegt immer im Repository: Sperren und Sicherungen müssen für
+	// alle Sitzungen auf dem Rechner sichtbar sein.
+	metaDir = ".adronaut"
+
+	// adrDir ist das ADR-Verzeichnis relativ zur Repo-Wurzel; nach
+	// initWorkspace ist die Wurzel das Arbeitsverzeichnis.
+	adrDir = "."
+	// homeADRDir ist das konfigurierte bzw. gefundene Verzeichnis; leer, wenn
+	// keines gefunden wurde. Weicht adrDir davon ab (--dir), wird gewarnt.
+	homeADRDir = "."
+	// startDir ist das Verzeichnis, aus dem ADRonaut gestartet wurde.
+	startDir = "."
+
+	// startupNotice wird beim Start im Picker angezeigt (z. B. nach einer
+	// Migration der Entwürfe).
+	startupNotice string
+)
+
+// findRoot sucht von dir aufwärts nach .git oder .adronaut.json; ohne
+// Treffer bleibt es bei dir.
+func findRoot(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	for d := abs; ; d = filepath.Dir(d) {
+		for _, marker := range []string{".git", configFile} {
+			if _, err := os.Stat(filepath.Join(d, marker)); err == nil {
+				return d
+			}
+		}
+		if filepath.Dir(d) == d {
+			return abs
+		}
+	}
+}
+
+func loadConfig(root string) (config, error) {
+	var c config
+	b, err := os.ReadFile(filepath.Join(root, configFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, fmt.Errorf("%s: %w", configFile, err)
+	}
+	return c, nil
+}
+
+// stateHome folgt der XDG-Spezifikation ($XDG_STATE_HOME, sonst
+// ~/.local/state).
+func stateHome() (string, error) {
+	if d := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(d) {
+		return d, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state"), nil
+}
+
+// repoID macht den Zustandsordner eindeutig je Repository-Pfad und bleibt
+// trotzdem lesbar.
+func repoID(root string) string {
+	return filepath.Base(root) + "-" + contentHash(filepath.ToSlash(root))[:12]
+}
+
+// resolveADRDir: Konfiguration vor bekannten Orten mit ADRs vor der Wurzel
+// selbst vor bekannten, noch leeren Orten. Leer, wenn nichts passt.
+func resolveADRDir(root string, cfg config) string {
+	if cfg.ADRDir != "" {
+		return filepath.Clean(filepath.FromSlash(cfg.ADRDir))
+	}
+	for _, c := range adrDirCandidates {
+		if len(scanADRFiles(filepath.Join(root, c))) > 0 {
+			return filepath.FromSlash(c)
+		}
+	}
+	if len(scanADRFiles(root)) > 0 {
+		return "."
+	}
+	for _, c := range adrDirCandidates {
+		if st, err := os.Stat(filepath.Join(root, c)); err == nil && st.IsDir() {
+			return filepath.FromSlash(c)
+		}
+	}
+	return ""
+}
+
+// userPath übersetzt einen Pfad, den der Benutzer relativ zum Startverzeichnis
+// angegeben hat, in einen Pfad relativ zur Repo-Wurzel.
+func userPath(p string) string {
+	if p == "" || filepath.IsAbs(p) {
+		return p
+	}
+	abs := filepath.Join(startDir, p)
+	root, err := os.Getwd()
+	if err != nil {
+		return abs
+	}
+	if rel, err := filepath.Rel(root, abs); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return abs
+}
+
+// initWorkspace sucht die Repo-Wurzel, wechselt dorthin, bestimmt das
+// ADR-Verzeichnis (dirFlag überschreibt) und die Ablageorte und zieht
+// Entwürfe vom jeweils anderen Ort um.
+func initWorkspace(dirFlag string) error {
+	var err error
+	if startDir, err = os.Getwd(); err != nil {
+		return err
+	}
+	root := findRoot(".")
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(root); err != nil {
+		return err
+	}
+	if numbering, err = checkNumbering(cfg.Numbering); err != nil {
+		return err
+	}
+	homeADRDir = resolveADRDir(root, cfg)
+	adrDir = homeADRDir
+	if adrDir == "" {
+		adrDir = "."
+	}
+	if dirFlag != "" {
+		adrDir = userPath(dirFlag)
+	}
+	adrRoots = discoverADRRoots(".", adrDir, cfg.Roots)
+	inRepo := filepath.Join(root, ".adronaut")
+	metaDir = inRepo
+
+	state := ""
+	if sh, err := stateHome(); err == nil {
+		state = filepath.Join(sh, "adronaut", repoID(root))
+	}
+	switch strings.ToLower(cfg.Drafts) {
+	case "", "state":
+		autosaveDir = inRepo // kein Home-Verzeichnis: im Repo bleiben
+		if state != "" {
+			autosaveDir = state
+			err = migrateDrafts(inRepo, state)
+		}
+	case "repo":
+		autosaveDir = inRepo
+		if state != "" {
+			err = migrateDrafts(state, inRepo)
+		}
+	default:
+		return fmt.Errorf("%s: unbekannter Wert %q für \"drafts\" (erlaubt: state, repo)", configFile, cfg.Drafts)
+	}
+	if err != nil {
+		return err
+	}
+	return renameDrafts()
+}
+
+// migrateDrafts verschiebt Entwürfe samt Historie und Rückgängig-Stapel von
+// from nach to. Bereits vorhandene Ziele werden nicht überschrieben.
+func migrateDrafts(from, to string) error {
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return nil
+	}
+	moved := 0
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".draft.json") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(to, name)); err == nil {
+			continue
+		}
+		if err := moveDraft(from, name, to, name); err != nil {
+			return err
+		}
+		moved++
+	}
+	if moved > 0 {
+		startupNotice = fmt.Sprintf("%d Entwurf/Entwürfe nach %s umgezogen", moved, to)
+	}
+	return nil
+}
+
+// moveDraft verschiebt den Entwurf fromDir/from samt Rückgängig-Stapel und
+// Historie nach toDir/to.
+func moveDraft(fromDir, from, toDir, to string) error {
+	src, dst := filepath.Join(fromDir, from), filepath.Join(toDir, to)
+	if err := moveFile(src, dst); err != nil {
+		return fmt.Errorf("Entwurf %s konnte nicht umgezogen werden: %w", from, err)
+	}
+	_ = moveFile(undoPath(src), undoPath(dst))
+	_ = moveDir(snapshotDirIn(fromDir, from), snapshotDirIn(toDir, to))
+	return nil
+}
+
+// renameDrafts: Entwürfe zu ADRs außerhalb des Hauptverzeichnisses hießen
+// früher nur nach dem Dateinamen und konnten so zwischen Teilbäumen
+// kollidieren. Sie bekommen den Namen, den draftName heute vergibt.
+func renameDrafts() error {
+	entries, err := os.ReadDir(autosaveDir)
+	if err != nil {
+		return nil
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".draft.json") || strings.HasPrefix(name, "new-") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(autosaveDir, name))
+		if err != nil {
+			continue
+		}
+		df, err := decodeDraft(b)
+		if err != nil || df.EditingPath == "" {
+			continue
+		}
+		want := draftName(df.EditingPath)
+		if want == name {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(autosaveDir, want)); err == nil {
+			continue
+		}
+		if err := moveDraft(autosaveDir, name, autosaveDir, want); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveFile benennt um und kopiert notfalls (anderes Dateisystem).
+func moveFile(src, dst string) error {
+	if _, err := os.Stat(src); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if os.Rename(src, dst) == nil {
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	b, err := io.ReadAll(in)
+	in.Close()
+	if err != nil {
+		return err
+	}
+	if err := atomicWrite(dst, b, 0o644); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
+
+func moveDir(src, dst string) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := moveFile(filepath.Join(src, e.Name()), filepath.Join(dst, e.Name())); err != nil {
+			return err
+		}
+	}
+	return os.Remove(src)
+}
+
+// placementWarning warnt, bevor ein neuer ADR außerhalb des konfigurierten
+// bzw. gefundenen ADR-Verzeichnisses entsteht.
+func placementWarning(dir string) string {
+	switch {
+	case homeADRDir == "":
+		return fmt.Sprintf("Kein ADR-Verzeichnis gefunden – der ADR wird in %s angelegt. Mit \"adr_dir\" in %s festlegen.", displayDir(dir), configFile)
+	case !isADRRoot(dir) && filepath.Clean(dir) != filepath.Clean(homeADRDir):
+		return fmt.Sprintf("Der ADR wird in %s angelegt, nicht im ADR-Verzeichnis %s.", displayDir(dir), displayDir(homeADRDir))
+	}
+	return ""
+}
+
+func displayDir(d string) string {
+	if d == "." {
+		return "der Repo-Wurzel"
+	}
+	return d + "/"
+}
+
+// ensureMetaDir legt .adronaut/ im Repository an – mit einer .gitignore,
+// damit Sperren und Sicherungen nicht versehentlich eingecheckt werden.
+func ensureMetaDir(sub string) (string, error) {
+	dir := filepath.Join(metaDir, sub)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	gi := filepath.Join(metaDir, ".gitignore")
+	if _, err := os.Stat(gi); errors.Is(err, os.ErrNotExist) {
+		_ = os.WriteFile(gi, []byte("*\n"), 0o644)
+	}
+	return dir, nil
+}